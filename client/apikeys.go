@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ApiKey is a key stored by the server. The config file keys are not listed.
+type ApiKey struct {
+	Name      string    `json:"name"`
+	Tenant    string    `json:"tenant"`
+	Admin     bool      `json:"admin"`
+	CreatedAt time.Time `json:"created_at"`
+	// Key is only returned by CreateApiKey
+	Key string `json:"key,omitempty"`
+}
+
+func (k *ApiKey) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name      string `json:"name"`
+		Tenant    string `json:"tenant"`
+		Admin     bool   `json:"admin"`
+		CreatedAt string `json:"created_at"`
+		Key       string `json:"key"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	createdAt, err := parseTime(raw.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	*k = ApiKey{
+		Name:      raw.Name,
+		Tenant:    raw.Tenant,
+		Admin:     raw.Admin,
+		CreatedAt: createdAt,
+		Key:       raw.Key,
+	}
+
+	return nil
+}
+
+// ListApiKeys needs an admin key.
+func (client *Client) ListApiKeys(ctx context.Context) ([]ApiKey, error) {
+	var apiKeys []ApiKey
+	_, err := client.do(ctx, retryIdempotent, http.MethodGet, "/v1/api-keys", nil, nil, &apiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+// CreateApiKey has the server generate a key named name for tenant, the
+// empty string being the default tenant. It needs an admin key.
+func (client *Client) CreateApiKey(ctx context.Context, name string, tenant string, admin bool) (*ApiKey, error) {
+	var apiKey ApiKey
+	_, err := client.do(ctx, retryRateLimited, http.MethodPost, "/v1/api-keys", nil, map[string]interface{}{
+		"name":   name,
+		"tenant": tenant,
+		"admin":  admin,
+	}, &apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+// RevokeApiKey removes the key named name. The server keeps accepting it for
+// a few seconds. It needs an admin key.
+func (client *Client) RevokeApiKey(ctx context.Context, name string) error {
+	_, err := client.do(ctx, retryIdempotent, http.MethodDelete, "/v1/api-keys/"+url.PathEscape(name), nil, nil, nil)
+	return err
+}
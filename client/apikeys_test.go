@@ -0,0 +1,83 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"lean-queue/client"
+	InfrastructureRoutes "lean-queue/src/infrastructure/routes"
+	"net/http"
+	"testing"
+)
+
+func TestCreateListAndRevokeApiKeys(t *testing.T) {
+	repository := newMemoryRepository()
+	server := newTestServer(t, repository, InfrastructureRoutes.Config{
+		AdminApiKeys: map[string]bool{testApiKey: true},
+	}, nil)
+	admin := client.New(server.URL, testApiKey)
+	ctx := context.Background()
+
+	created, err := admin.CreateApiKey(ctx, "acme-worker", "acme", false)
+	if err != nil {
+		t.Fatalf("CreateApiKey failed: %v", err)
+	}
+	if created.Key == "" || created.Tenant != "acme" || created.Admin {
+		t.Fatalf("CreateApiKey = %+v, want a non admin key of acme", created)
+	}
+
+	_, err = admin.CreateApiKey(ctx, "acme-worker", "", true)
+	var serverError *client.Error
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusConflict {
+		t.Fatalf("CreateApiKey with a taken name = %v, want a conflict", err)
+	}
+
+	worker := client.New(server.URL, created.Key)
+	if _, err := worker.Publish(ctx, client.PublishRequest{QueueName: "orders", Message: "first"}); err != nil {
+		t.Fatalf("Publish with the created key failed: %v", err)
+	}
+	if tenant := repository.messages[0].GetName().GetTenant(); tenant != "acme" {
+		t.Errorf("message stored for tenant %q, want acme", tenant)
+	}
+
+	_, err = worker.ListApiKeys(ctx)
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusForbidden {
+		t.Fatalf("ListApiKeys with a non admin key = %v, want forbidden", err)
+	}
+
+	apiKeys, err := admin.ListApiKeys(ctx)
+	if err != nil {
+		t.Fatalf("ListApiKeys failed: %v", err)
+	}
+	if len(apiKeys) != 1 || apiKeys[0].Name != "acme-worker" || apiKeys[0].Key != "" || apiKeys[0].CreatedAt.IsZero() {
+		t.Fatalf("ListApiKeys = %+v, want acme-worker without its key", apiKeys)
+	}
+
+	if err := admin.RevokeApiKey(ctx, "acme-worker"); err != nil {
+		t.Fatalf("RevokeApiKey failed: %v", err)
+	}
+	if err := admin.RevokeApiKey(ctx, "acme-worker"); !client.IsNotFound(err) {
+		t.Fatalf("RevokeApiKey of a revoked key = %v, want not found", err)
+	}
+
+	_, err = client.New(server.URL, "unknown-key").ListQueues(ctx)
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("ListQueues with an unknown key = %v, want unauthorized", err)
+	}
+}
+
+func TestStoredAdminKeyRunsAdminOperations(t *testing.T) {
+	repository := newMemoryRepository()
+	server := newTestServer(t, repository, InfrastructureRoutes.Config{
+		AdminApiKeys: map[string]bool{testApiKey: true},
+	}, nil)
+	ctx := context.Background()
+
+	created, err := client.New(server.URL, testApiKey).CreateApiKey(ctx, "operator", "", true)
+	if err != nil {
+		t.Fatalf("CreateApiKey failed: %v", err)
+	}
+
+	if _, err := client.New(server.URL, created.Key).CreateApiKey(ctx, "other", "", false); err != nil {
+		t.Fatalf("CreateApiKey with a stored admin key failed: %v", err)
+	}
+}
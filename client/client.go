@@ -0,0 +1,269 @@
+// Package client talks to a LeanQueue server: it publishes, reserves,
+// acknowledges and extends messages, retrying the transient failures of the
+// calls that are safe to repeat, and runs consumers processing the messages
+// of a queue.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const timeLayout = "2006-01-02 15:04:05.999999"
+
+type Client struct {
+	baseUrl        string
+	apiKey         string
+	httpClient     *http.Client
+	maxAttempts    int
+	retryBase      time.Duration
+	retryMax       time.Duration
+	acceptLanguage string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default client, which times out after 30s.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
+// WithRetry makes calls try up to maxAttempts times, waiting between
+// attempts an exponential backoff from base up to max, with jitter. Calls
+// that must not run twice, such as Reserve, only retry rate limited answers.
+func WithRetry(maxAttempts int, base time.Duration, max time.Duration) Option {
+	return func(client *Client) {
+		client.maxAttempts = maxAttempts
+		client.retryBase = base
+		client.retryMax = max
+	}
+}
+
+// WithAcceptLanguage asks for error messages in language, such as "pt".
+func WithAcceptLanguage(language string) Option {
+	return func(client *Client) {
+		client.acceptLanguage = language
+	}
+}
+
+// New returns a client of the server at baseUrl, such as
+// "http://localhost:8080", authenticated by apiKey.
+func New(baseUrl string, apiKey string, options ...Option) *Client {
+	client := &Client{
+		baseUrl:     strings.TrimRight(baseUrl, "/"),
+		apiKey:      apiKey,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		maxAttempts: 4,
+		retryBase:   200 * time.Millisecond,
+		retryMax:    5 * time.Second,
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	if client.maxAttempts < 1 {
+		client.maxAttempts = 1
+	}
+
+	return client
+}
+
+// Error is an error answered by the server.
+type Error struct {
+	StatusCode int
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	Details    map[string]interface{} `json:"details"`
+	retryAfter time.Duration
+	body       []byte
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("leanqueue: %s (%d %s)", e.Message, e.StatusCode, e.Code)
+}
+
+// IsNotFound tells whether err is a not found answer of the server.
+func IsNotFound(err error) bool {
+	var serverError *Error
+	return errors.As(err, &serverError) && serverError.StatusCode == http.StatusNotFound
+}
+
+// temporary tells whether repeating the request may succeed.
+func (e *Error) temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+type retryPolicy int
+
+const (
+	// retryIdempotent retries network failures and temporary answers, for
+	// calls whose effect is the same when repeated
+	retryIdempotent retryPolicy = iota
+	// retryRateLimited only retries 429 answers, given before the server does
+	// anything, for calls whose lost answer may hide that they ran
+	retryRateLimited
+)
+
+// do sends the request, retrying failures as retry allows, and decodes the
+// JSON answer into output when given.
+func (client *Client) do(ctx context.Context, retry retryPolicy, method string, path string, query url.Values, input interface{}, output interface{}) (http.Header, error) {
+	var body []byte
+	if input != nil {
+		var err error
+		body, err = json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := client.baseUrl + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < client.maxAttempts; attempt++ {
+		if attempt > 0 {
+			wait := client.backoff(attempt)
+			var serverError *Error
+			if errors.As(lastErr, &serverError) && serverError.retryAfter > wait {
+				wait = serverError.retryAfter
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		header, err := client.send(ctx, method, endpoint, body, output)
+		if err == nil {
+			return header, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var serverError *Error
+		isServerError := errors.As(err, &serverError)
+		if isServerError && !serverError.temporary() {
+			return nil, err
+		}
+		if retry == retryRateLimited && (!isServerError || serverError.StatusCode != http.StatusTooManyRequests) {
+			return nil, err
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+func (client *Client) send(ctx context.Context, method string, endpoint string, body []byte, output interface{}) (http.Header, error) {
+	var reader io.Reader
+	contentType := ""
+	if body != nil {
+		reader = bytes.NewReader(body)
+		contentType = "application/json"
+	}
+
+	response, err := client.open(ctx, method, endpoint, contentType, reader)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if output != nil {
+		if err := json.NewDecoder(response.Body).Decode(output); err != nil {
+			return nil, err
+		}
+	}
+
+	return response.Header, nil
+}
+
+// open sends the request once and returns the response of a success, whose
+// body the caller closes, or the Error answered.
+func (client *Client) open(ctx context.Context, method string, endpoint string, contentType string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("ApiAuthorization", client.apiKey)
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if client.acceptLanguage != "" {
+		request.Header.Set("Accept-Language", client.acceptLanguage)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 300 {
+		defer response.Body.Close()
+		content, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+		serverError := &Error{StatusCode: response.StatusCode, body: content}
+		if json.Unmarshal(content, serverError) != nil || serverError.Code == "" {
+			serverError.Message = strings.TrimSpace(string(content))
+		}
+		if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil {
+			serverError.retryAfter = time.Duration(seconds) * time.Second
+		}
+		return nil, serverError
+	}
+
+	return response, nil
+}
+
+func (client *Client) backoff(attempt int) time.Duration {
+	wait := client.retryBase << (attempt - 1)
+	if wait <= 0 || wait > client.retryMax {
+		wait = client.retryMax
+	}
+
+	// Full jitter keeps consumers failing together from retrying together
+	return time.Duration(rand.Int63n(int64(wait) + 1))
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.ParseInLocation(timeLayout, value, time.UTC)
+}
+
+func parseOptionalTime(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	parsed, err := parseTime(*value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
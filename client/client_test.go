@@ -0,0 +1,218 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"lean-queue/client"
+	InfrastructureRoutes "lean-queue/src/infrastructure/routes"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPublishReserveAckAndExtend(t *testing.T) {
+	repository := newMemoryRepository()
+	server := newTestServer(t, repository, InfrastructureRoutes.Config{}, nil)
+	queues := client.New(server.URL, testApiKey)
+	ctx := context.Background()
+
+	published, err := queues.Publish(ctx, client.PublishRequest{
+		QueueName:  "orders",
+		Message:    "first",
+		Attributes: map[string]string{"type": "order"},
+	})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if published.MessageId == "" || published.Deduplicated {
+		t.Fatalf("Publish = %+v, want a new message id", published)
+	}
+
+	deduplicated, err := queues.Publish(ctx, client.PublishRequest{QueueName: "orders", Message: "first again", DeduplicationId: "first"})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	again, err := queues.Publish(ctx, client.PublishRequest{QueueName: "orders", Message: "first once more", DeduplicationId: "first"})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if deduplicated.Deduplicated || !again.Deduplicated || again.MessageId != deduplicated.MessageId {
+		t.Fatalf("Publish = %+v then %+v, want the repeated deduplication id answered with the first message", deduplicated, again)
+	}
+
+	batch, err := queues.PublishBatch(ctx, "orders", []client.BatchMessage{
+		{Message: "second"},
+		{Message: "third", Attributes: map[string]string{"type": "refund"}},
+	})
+	if err != nil {
+		t.Fatalf("PublishBatch failed: %v", err)
+	}
+	if len(batch) != 2 || batch[0] == "" || batch[0] == batch[1] {
+		t.Fatalf("PublishBatch = %v, want two new message ids", batch)
+	}
+	if repository.stored() != 4 {
+		t.Fatalf("server stores %d messages, want 4", repository.stored())
+	}
+
+	messages, err := queues.Reserve(ctx, client.ReserveRequest{
+		QueueName:  "orders",
+		ReservedBy: "worker-1",
+		Limit:      10,
+		ReserveFor: time.Minute,
+		Filters:    []string{"type=order"},
+	})
+	if err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Id != published.MessageId || messages[0].Message != "first" {
+		t.Fatalf("Reserve = %+v, want the first message only", messages)
+	}
+	reserved := messages[0]
+	if reserved.ReservedBy == nil || *reserved.ReservedBy != "worker-1" || reserved.ReservedCount != 1 || reserved.Attributes["type"] != "order" {
+		t.Errorf("reserved message = %+v, want reserved once by worker-1 with its attributes", reserved)
+	}
+
+	messages, err = queues.Reserve(ctx, client.ReserveRequest{QueueName: "orders", ReservedBy: "worker-2", Limit: 10})
+	if err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("Reserve returned %d messages, want the 3 not reserved yet", len(messages))
+	}
+
+	reserveExpires, err := queues.Extend(ctx, reserved.Id, "worker-1", 2*time.Minute)
+	if err != nil {
+		t.Fatalf("Extend failed: %v", err)
+	}
+	if until := time.Until(reserveExpires); until < time.Minute || until > 2*time.Minute {
+		t.Errorf("Extend expires in %s, want about 2m", until)
+	}
+
+	_, err = queues.Extend(ctx, reserved.Id, "worker-2", time.Minute)
+	if !client.IsNotFound(err) {
+		t.Errorf("Extend by another consumer = %v, want a not found error", err)
+	}
+
+	if err := queues.Ack(ctx, reserved.Id); err != nil {
+		t.Fatalf("Ack failed: %v", err)
+	}
+	if completed := repository.completedIds(); len(completed) != 1 || completed[0] != reserved.Id {
+		t.Errorf("server completed %v, want %s", completed, reserved.Id)
+	}
+
+	_, err = queues.Extend(ctx, reserved.Id, "worker-1", time.Minute)
+	if !client.IsNotFound(err) {
+		t.Errorf("Extend of an acknowledged message = %v, want a not found error", err)
+	}
+}
+
+func TestRetriesUnavailableServer(t *testing.T) {
+	var attempts atomic.Int32
+	server := newTestServer(t, newMemoryRepository(), InfrastructureRoutes.Config{}, func(w http.ResponseWriter, r *http.Request) bool {
+		if attempts.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		}
+		return false
+	})
+	queues := client.New(server.URL, testApiKey, client.WithRetry(3, time.Millisecond, 10*time.Millisecond))
+
+	_, err := queues.Publish(context.Background(), client.PublishRequest{QueueName: "orders", Message: "first", DeduplicationId: "order-1"})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Publish took %d attempts, want 3", attempts.Load())
+	}
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	var attempts atomic.Int32
+	server := newTestServer(t, newMemoryRepository(), InfrastructureRoutes.Config{}, func(w http.ResponseWriter, r *http.Request) bool {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	})
+	queues := client.New(server.URL, testApiKey, client.WithRetry(3, time.Millisecond, 10*time.Millisecond))
+
+	_, err := queues.Publish(context.Background(), client.PublishRequest{QueueName: "orders", Message: "first", DeduplicationId: "order-1"})
+	var serverError *client.Error
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Publish = %v, want the 503 error", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Publish took %d attempts, want 3", attempts.Load())
+	}
+}
+
+func TestDoesNotRetryUnsafeCallsOnUnavailableServer(t *testing.T) {
+	var attempts atomic.Int32
+	server := newTestServer(t, newMemoryRepository(), InfrastructureRoutes.Config{}, func(w http.ResponseWriter, r *http.Request) bool {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+		return true
+	})
+	queues := client.New(server.URL, testApiKey, client.WithRetry(3, time.Millisecond, 10*time.Millisecond))
+	ctx := context.Background()
+
+	if _, err := queues.Publish(ctx, client.PublishRequest{QueueName: "orders", Message: "first"}); err == nil {
+		t.Fatalf("Publish without a deduplication id succeeded, want the 502 error")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Publish without a deduplication id took %d attempts, want 1", attempts.Load())
+	}
+
+	attempts.Store(0)
+	if _, err := queues.Reserve(ctx, client.ReserveRequest{QueueName: "orders", ReservedBy: "worker-1"}); err == nil {
+		t.Fatalf("Reserve succeeded, want the 502 error")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Reserve took %d attempts, want 1", attempts.Load())
+	}
+}
+
+func TestRetriesRateLimitAfterRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	server := newTestServer(t, newMemoryRepository(), rateLimited, func(w http.ResponseWriter, r *http.Request) bool {
+		attempts.Add(1)
+		return false
+	})
+	queues := client.New(server.URL, testApiKey, client.WithRetry(3, time.Millisecond, 10*time.Millisecond))
+	ctx := context.Background()
+
+	if _, err := queues.Publish(ctx, client.PublishRequest{QueueName: "orders", Message: "first"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	startedAt := time.Now()
+	if _, err := queues.Publish(ctx, client.PublishRequest{QueueName: "orders", Message: "second"}); err != nil {
+		t.Fatalf("rate limited Publish failed: %v", err)
+	}
+
+	// The backoff is at most 10ms, so waiting longer is the Retry-After
+	if elapsed := time.Since(startedAt); elapsed < time.Second {
+		t.Errorf("rate limited Publish retried after %s, want the 1s of Retry-After", elapsed)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("publishes took %d attempts, want 3", attempts.Load())
+	}
+}
+
+func TestDoesNotRetryClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := newTestServer(t, newMemoryRepository(), InfrastructureRoutes.Config{}, func(w http.ResponseWriter, r *http.Request) bool {
+		attempts.Add(1)
+		return false
+	})
+	queues := client.New(server.URL, testApiKey, client.WithRetry(3, time.Millisecond, 10*time.Millisecond))
+
+	_, err := queues.Publish(context.Background(), client.PublishRequest{QueueName: "bad name", Message: "first"})
+	var serverError *client.Error
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusBadRequest || serverError.Code != "validation_failed" {
+		t.Fatalf("Publish = %v, want a validation error", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Publish took %d attempts, want 1", attempts.Load())
+	}
+}
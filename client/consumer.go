@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Handler processes a message. Returning nil acknowledges it; an error
+// leaves it to be delivered again once its reservation expires.
+type Handler func(ctx context.Context, message Message) error
+
+type ConsumerConfig struct {
+	QueueName  string
+	ReservedBy string
+	// Concurrency is how many messages are handled at once, 1 when zero.
+	Concurrency int
+	// ReserveFor is how long each reservation lasts, 60s when zero.
+	ReserveFor time.Duration
+	// Heartbeat is how often the reservation of a message being handled is
+	// extended by ReserveFor, a third of ReserveFor when zero.
+	Heartbeat time.Duration
+	// PollInterval is the wait after finding the queue empty, 1s when zero.
+	PollInterval time.Duration
+	// ShutdownTimeout bounds how long Run waits for the handlers once
+	// stopped, after which their context is cancelled. Zero waits forever.
+	ShutdownTimeout time.Duration
+	Filters         []string
+	// OnError is told about failures that do not stop the consumer, such as
+	// handler errors or failed acknowledgements.
+	OnError func(message *Message, err error)
+}
+
+type Consumer struct {
+	client  *Client
+	config  ConsumerConfig
+	handler Handler
+}
+
+func (client *Client) NewConsumer(config ConsumerConfig, handler Handler) *Consumer {
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	if config.ReserveFor <= 0 {
+		config.ReserveFor = 60 * time.Second
+	}
+	if config.Heartbeat <= 0 {
+		config.Heartbeat = config.ReserveFor / 3
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.OnError == nil {
+		config.OnError = func(*Message, error) {}
+	}
+
+	return &Consumer{
+		client:  client,
+		config:  config,
+		handler: handler,
+	}
+}
+
+// maxReserveLimit is the most messages the server reserves at once.
+const maxReserveLimit = 100
+
+// Run reserves and handles messages until ctx is done, then stops reserving
+// and waits for the messages being handled before returning.
+func (consumer *Consumer) Run(ctx context.Context) error {
+	if consumer.config.QueueName == "" || consumer.config.ReservedBy == "" {
+		return errors.New("leanqueue: consumer needs a queue name and reserved by")
+	}
+
+	// Handlers outlive ctx so a stop lets them finish their message
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+
+	slots := make(chan struct{}, consumer.config.Concurrency)
+	var handlers sync.WaitGroup
+
+	for ctx.Err() == nil {
+		// Wait for a free slot, then take every other free one
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			continue
+		}
+		free := 1
+		for free < consumer.config.Concurrency && free < maxReserveLimit && tryAcquire(slots) {
+			free++
+		}
+
+		messages, err := consumer.client.Reserve(ctx, ReserveRequest{
+			QueueName:  consumer.config.QueueName,
+			ReservedBy: consumer.config.ReservedBy,
+			Limit:      free,
+			ReserveFor: consumer.config.ReserveFor,
+			Filters:    consumer.config.Filters,
+		})
+		if err != nil && ctx.Err() == nil {
+			consumer.config.OnError(nil, err)
+		}
+
+		for i := len(messages); i < free; i++ {
+			<-slots
+		}
+
+		for _, message := range messages {
+			handlers.Add(1)
+			go func(message Message) {
+				defer handlers.Done()
+				defer func() { <-slots }()
+				consumer.handle(handlerCtx, message)
+			}(message)
+		}
+
+		if len(messages) == 0 {
+			sleep(ctx, consumer.config.PollInterval)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		handlers.Wait()
+		close(done)
+	}()
+
+	if consumer.config.ShutdownTimeout > 0 {
+		select {
+		case <-done:
+		case <-time.After(consumer.config.ShutdownTimeout):
+			cancelHandlers()
+		}
+	}
+	<-done
+
+	return nil
+}
+
+// handle runs the handler while a heartbeat keeps the message reserved,
+// acknowledging it when the handler succeeds.
+func (consumer *Consumer) handle(ctx context.Context, message Message) {
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	var heartbeat sync.WaitGroup
+	heartbeat.Add(1)
+	go func() {
+		defer heartbeat.Done()
+		consumer.heartbeat(heartbeatCtx, message)
+	}()
+
+	err := consumer.handler(ctx, message)
+	stopHeartbeat()
+	heartbeat.Wait()
+
+	if err != nil {
+		consumer.config.OnError(&message, err)
+		return
+	}
+
+	if err := consumer.client.Ack(ctx, message.Id); err != nil {
+		consumer.config.OnError(&message, err)
+	}
+}
+
+func (consumer *Consumer) heartbeat(ctx context.Context, message Message) {
+	ticker := time.NewTicker(consumer.config.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := consumer.client.Extend(ctx, message.Id, consumer.config.ReservedBy, consumer.config.ReserveFor)
+			if err != nil && ctx.Err() == nil {
+				consumer.config.OnError(&message, err)
+				if IsNotFound(err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func tryAcquire(slots chan struct{}) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
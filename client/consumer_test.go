@@ -0,0 +1,200 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"lean-queue/client"
+	InfrastructureRoutes "lean-queue/src/infrastructure/routes"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func publish(t *testing.T, queues *client.Client, count int) []string {
+	t.Helper()
+
+	ids := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		result, err := queues.Publish(context.Background(), client.PublishRequest{QueueName: "jobs", Message: fmt.Sprintf("job %d", i)})
+		if err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+		ids = append(ids, result.MessageId)
+	}
+
+	return ids
+}
+
+// runConsumer runs consumer until the returned stop is called, which waits
+// for Run to return.
+func runConsumer(t *testing.T, consumer *client.Consumer) (stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- consumer.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run failed: %v", err)
+		}
+	}
+}
+
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestConsumerRespectsConcurrency(t *testing.T) {
+	repository := newMemoryRepository()
+	server := newTestServer(t, repository, InfrastructureRoutes.Config{}, nil)
+	queues := client.New(server.URL, testApiKey)
+	publish(t, queues, 12)
+
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	consumer := queues.NewConsumer(client.ConsumerConfig{
+		QueueName:    "jobs",
+		ReservedBy:   "worker",
+		Concurrency:  3,
+		PollInterval: 10 * time.Millisecond,
+	}, func(ctx context.Context, message client.Message) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	})
+
+	stop := runConsumer(t, consumer)
+	waitFor(t, "every message to be acknowledged", func() bool { return len(repository.completedIds()) == 12 })
+	stop()
+
+	if maxRunning.Load() != 3 {
+		t.Errorf("at most %d handlers ran at once, want 3", maxRunning.Load())
+	}
+}
+
+func TestConsumerSendsHeartbeats(t *testing.T) {
+	repository := newMemoryRepository()
+	server := newTestServer(t, repository, InfrastructureRoutes.Config{}, nil)
+	queues := client.New(server.URL, testApiKey)
+	ids := publish(t, queues, 1)
+
+	consumer := queues.NewConsumer(client.ConsumerConfig{
+		QueueName:    "jobs",
+		ReservedBy:   "worker",
+		ReserveFor:   time.Second,
+		Heartbeat:    20 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+		OnError: func(message *client.Message, err error) {
+			t.Errorf("consumer failed: %v", err)
+		},
+	}, func(ctx context.Context, message client.Message) error {
+		time.Sleep(150 * time.Millisecond)
+		return nil
+	})
+
+	stop := runConsumer(t, consumer)
+	waitFor(t, "the message to be acknowledged", func() bool { return len(repository.completedIds()) == 1 })
+	stop()
+
+	if extensions := repository.extensionsOf(ids[0]); extensions < 3 {
+		t.Errorf("the reservation was extended %d times while handled, want at least 3", extensions)
+	}
+}
+
+func TestConsumerLetsHandlersFinishOnStop(t *testing.T) {
+	repository := newMemoryRepository()
+	server := newTestServer(t, repository, InfrastructureRoutes.Config{}, nil)
+	queues := client.New(server.URL, testApiKey)
+	publish(t, queues, 2)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	var cancelled atomic.Bool
+	consumer := queues.NewConsumer(client.ConsumerConfig{
+		QueueName:    "jobs",
+		ReservedBy:   "worker",
+		Concurrency:  2,
+		PollInterval: 10 * time.Millisecond,
+	}, func(ctx context.Context, message client.Message) error {
+		started <- struct{}{}
+		<-release
+		cancelled.Store(ctx.Err() != nil)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var returned atomic.Bool
+	var run sync.WaitGroup
+	run.Add(1)
+	go func() {
+		defer run.Done()
+		consumer.Run(ctx)
+		returned.Store(true)
+	}()
+
+	<-started
+	<-started
+	cancel()
+
+	time.Sleep(50 * time.Millisecond)
+	if returned.Load() {
+		t.Fatal("Run returned before its handlers finished")
+	}
+
+	close(release)
+	run.Wait()
+
+	if cancelled.Load() {
+		t.Error("handlers were cancelled by the stop")
+	}
+	if completed := repository.completedIds(); len(completed) != 2 {
+		t.Errorf("%d messages were acknowledged after the stop, want 2", len(completed))
+	}
+}
+
+func TestConsumerCancelsHandlersAfterShutdownTimeout(t *testing.T) {
+	repository := newMemoryRepository()
+	server := newTestServer(t, repository, InfrastructureRoutes.Config{}, nil)
+	queues := client.New(server.URL, testApiKey)
+	publish(t, queues, 1)
+
+	started := make(chan struct{})
+	consumer := queues.NewConsumer(client.ConsumerConfig{
+		QueueName:       "jobs",
+		ReservedBy:      "worker",
+		PollInterval:    10 * time.Millisecond,
+		ShutdownTimeout: 50 * time.Millisecond,
+	}, func(ctx context.Context, message client.Message) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	stop := runConsumer(t, consumer)
+	<-started
+	stop()
+
+	if repository.stored() != 1 {
+		t.Error("the message of a cancelled handler was acknowledged")
+	}
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Message struct {
+	Id             string            `json:"id"`
+	QueueName      string            `json:"queue_name"`
+	Message        string            `json:"message"`
+	Attributes     map[string]string `json:"attributes"`
+	GroupId        *string           `json:"group_id"`
+	ExpiresAt      *time.Time        `json:"expires_at"`
+	PublishedAt    time.Time         `json:"published_at"`
+	ReservedAt     *time.Time        `json:"reserved_at"`
+	ReservedBy     *string           `json:"reserved_by"`
+	ReservedCount  int               `json:"reserved_count"`
+	ReservedInfo   *string           `json:"reserved_info"`
+	ReserveExpires time.Time         `json:"reserve_expires"`
+	Traceparent    *string           `json:"traceparent"`
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Id             string            `json:"id"`
+		QueueName      string            `json:"queue_name"`
+		Message        string            `json:"message"`
+		Attributes     map[string]string `json:"attributes"`
+		GroupId        *string           `json:"group_id"`
+		ExpiresAt      *string           `json:"expires_at"`
+		PublishedAt    string            `json:"published_at"`
+		ReservedAt     *string           `json:"reserved_at"`
+		ReservedBy     *string           `json:"reserved_by"`
+		ReservedCount  *int              `json:"reserved_count"`
+		ReservedInfo   *string           `json:"reserved_info"`
+		ReserveExpires string            `json:"reserve_expires"`
+		Traceparent    *string           `json:"traceparent"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	publishedAt, err := parseTime(raw.PublishedAt)
+	if err != nil {
+		return err
+	}
+
+	reserveExpires, err := parseTime(raw.ReserveExpires)
+	if err != nil {
+		return err
+	}
+
+	expiresAt, err := parseOptionalTime(raw.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	reservedAt, err := parseOptionalTime(raw.ReservedAt)
+	if err != nil {
+		return err
+	}
+
+	*m = Message{
+		Id:             raw.Id,
+		QueueName:      raw.QueueName,
+		Message:        raw.Message,
+		Attributes:     raw.Attributes,
+		GroupId:        raw.GroupId,
+		ExpiresAt:      expiresAt,
+		PublishedAt:    publishedAt,
+		ReservedAt:     reservedAt,
+		ReservedBy:     raw.ReservedBy,
+		ReservedInfo:   raw.ReservedInfo,
+		ReserveExpires: reserveExpires,
+		Traceparent:    raw.Traceparent,
+	}
+	if raw.ReservedCount != nil {
+		m.ReservedCount = *raw.ReservedCount
+	}
+
+	return nil
+}
+
+type PublishRequest struct {
+	QueueName  string
+	Message    string
+	Attributes map[string]string
+	// GroupId publishes into a FIFO group, whose messages are reserved one
+	// at a time in publish order.
+	GroupId   string
+	ExpiresAt *time.Time
+	Ttl       time.Duration
+	// DeduplicationId makes retried publishes safe: the server ignores ids
+	// it has already seen on the queue within its deduplication window.
+	DeduplicationId string
+}
+
+type PublishResult struct {
+	MessageId    string `json:"message_id"`
+	Deduplicated bool   `json:"deduplicated"`
+}
+
+// Publish stores a message in a queue. Network failures and unavailable
+// answers are only retried with a DeduplicationId; without one only rate
+// limited requests are, so a lost answer leaves the message maybe stored.
+func (client *Client) Publish(ctx context.Context, request PublishRequest) (*PublishResult, error) {
+	input := map[string]interface{}{
+		"queue_name": request.QueueName,
+		"message":    request.Message,
+	}
+	if len(request.Attributes) > 0 {
+		input["attributes"] = request.Attributes
+	}
+	if request.GroupId != "" {
+		input["group_id"] = request.GroupId
+	}
+	if request.ExpiresAt != nil {
+		input["expires_at"] = request.ExpiresAt.UTC().Format(time.RFC3339Nano)
+	}
+	if request.Ttl > 0 {
+		input["ttl_seconds"] = seconds(request.Ttl)
+	}
+	if request.DeduplicationId != "" {
+		input["deduplication_id"] = request.DeduplicationId
+	}
+
+	retry := retryRateLimited
+	if request.DeduplicationId != "" {
+		retry = retryIdempotent
+	}
+
+	var result PublishResult
+	_, err := client.do(ctx, retry, http.MethodPost, "/v1/message", nil, input, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// MaxBatchMessages is the most messages PublishBatch takes at once.
+const MaxBatchMessages = 100
+
+// BatchMessage is a message of PublishBatch, which publishes to a single
+// queue without deduplication.
+type BatchMessage struct {
+	Message    string
+	Attributes map[string]string
+	GroupId    string
+	ExpiresAt  *time.Time
+	Ttl        time.Duration
+}
+
+// PublishBatch stores up to MaxBatchMessages messages in a queue in a single request,
+// all of them or none, and returns their ids in order. Like a Publish
+// without DeduplicationId, only rate limited requests are retried.
+func (client *Client) PublishBatch(ctx context.Context, queueName string, messages []BatchMessage) ([]string, error) {
+	inputs := make([]map[string]interface{}, len(messages))
+	for i, message := range messages {
+		input := map[string]interface{}{
+			"message": message.Message,
+		}
+		if len(message.Attributes) > 0 {
+			input["attributes"] = message.Attributes
+		}
+		if message.GroupId != "" {
+			input["group_id"] = message.GroupId
+		}
+		if message.ExpiresAt != nil {
+			input["expires_at"] = message.ExpiresAt.UTC().Format(time.RFC3339Nano)
+		}
+		if message.Ttl > 0 {
+			input["ttl_seconds"] = seconds(message.Ttl)
+		}
+		inputs[i] = input
+	}
+
+	var result struct {
+		MessageIds []string `json:"message_ids"`
+	}
+	_, err := client.do(ctx, retryRateLimited, http.MethodPost, "/v1/message/batch", nil, map[string]interface{}{
+		"queue_name": queueName,
+		"messages":   inputs,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.MessageIds, nil
+}
+
+type ReserveRequest struct {
+	QueueName  string
+	ReservedBy string
+	// Limit is the most messages to reserve, 1 when zero.
+	Limit int
+	// ReserveFor is how long the messages stay hidden from other consumers,
+	// the server default of 60s when zero.
+	ReserveFor   time.Duration
+	ReservedInfo string
+	// Filters are attribute conditions such as "type=order" or "!urgent".
+	Filters []string
+}
+
+// Reserve hides up to Limit visible messages of the queue from other
+// consumers and returns them, possibly none. Only rate limited requests are
+// retried: the messages of a lost answer stay hidden until they expire.
+func (client *Client) Reserve(ctx context.Context, request ReserveRequest) ([]Message, error) {
+	query := url.Values{}
+	query.Set("queue_name", request.QueueName)
+	query.Set("reserved_by", request.ReservedBy)
+	if request.Limit > 0 {
+		query.Set("limit", strconv.Itoa(request.Limit))
+	}
+	if request.ReserveFor > 0 {
+		query.Set("reserve_by_seconds", strconv.Itoa(seconds(request.ReserveFor)))
+	}
+	if request.ReservedInfo != "" {
+		query.Set("reserved_info", request.ReservedInfo)
+	}
+	for _, filter := range request.Filters {
+		query.Add("filter", filter)
+	}
+
+	var messages []Message
+	_, err := client.do(ctx, retryRateLimited, http.MethodGet, "/v1/message/next", query, nil, &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// Ack removes a processed message.
+func (client *Client) Ack(ctx context.Context, messageId string) error {
+	_, err := client.do(ctx, retryIdempotent, http.MethodDelete, "/v1/message", nil, map[string]interface{}{"message_id": messageId}, nil)
+	return err
+}
+
+// Extend keeps a message reserved by reservedBy for reserveFor from now and
+// returns when the reservation expires. It fails with a not found Error
+// when the message was removed or reserved by another consumer.
+func (client *Client) Extend(ctx context.Context, messageId string, reservedBy string, reserveFor time.Duration) (time.Time, error) {
+	var result struct {
+		ReserveExpires string `json:"reserve_expires"`
+	}
+	_, err := client.do(ctx, retryIdempotent, http.MethodPost, "/v1/message/"+url.PathEscape(messageId)+"/extend", nil, map[string]interface{}{
+		"reserved_by":        reservedBy,
+		"reserve_by_seconds": seconds(reserveFor),
+	}, &result)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseTime(result.ReserveExpires)
+}
+
+// seconds rounds d up to whole seconds, the resolution of the server.
+func seconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
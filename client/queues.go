@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type QueueStats struct {
+	QueueName         string     `json:"queue_name"`
+	Messages          int64      `json:"messages"`
+	Visible           int64      `json:"visible"`
+	InFlight          int64      `json:"in_flight"`
+	Delayed           int64      `json:"delayed"`
+	Bytes             int64      `json:"bytes"`
+	OldestPublishedAt *time.Time `json:"oldest_published_at"`
+	Paused            bool       `json:"paused"`
+}
+
+func (qs *QueueStats) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		QueueName         string  `json:"queue_name"`
+		Messages          int64   `json:"messages"`
+		Visible           int64   `json:"visible"`
+		InFlight          int64   `json:"in_flight"`
+		Delayed           int64   `json:"delayed"`
+		Bytes             int64   `json:"bytes"`
+		OldestPublishedAt *string `json:"oldest_published_at"`
+		Paused            bool    `json:"paused"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	oldestPublishedAt, err := parseOptionalTime(raw.OldestPublishedAt)
+	if err != nil {
+		return err
+	}
+
+	*qs = QueueStats{
+		QueueName:         raw.QueueName,
+		Messages:          raw.Messages,
+		Visible:           raw.Visible,
+		InFlight:          raw.InFlight,
+		Delayed:           raw.Delayed,
+		Bytes:             raw.Bytes,
+		OldestPublishedAt: oldestPublishedAt,
+		Paused:            raw.Paused,
+	}
+
+	return nil
+}
+
+// ListQueues returns the stats of every queue holding messages or a config.
+func (client *Client) ListQueues(ctx context.Context) ([]QueueStats, error) {
+	var stats []QueueStats
+	_, err := client.do(ctx, retryIdempotent, http.MethodGet, "/v1/queues", nil, nil, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (client *Client) Stats(ctx context.Context, queueName string) (*QueueStats, error) {
+	var stats QueueStats
+	_, err := client.do(ctx, retryIdempotent, http.MethodGet, "/v1/queues/"+url.PathEscape(queueName)+"/stats", nil, nil, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+type ListRequest struct {
+	QueueName string
+	// Limit is the page size, 1 when zero.
+	Limit int
+	// Cursor is the next cursor returned with the previous page.
+	Cursor         string
+	States         []string
+	PublishedAfter *time.Time
+	Descending     bool
+}
+
+// ListMessages returns a page of messages of a queue without reserving them,
+// and the cursor of the next page, empty on the last one.
+func (client *Client) ListMessages(ctx context.Context, request ListRequest) ([]Message, string, error) {
+	query := url.Values{}
+	if request.Limit > 0 {
+		query.Set("limit", strconv.Itoa(request.Limit))
+	}
+	if request.Cursor != "" {
+		query.Set("cursor", request.Cursor)
+	}
+	for _, state := range request.States {
+		query.Add("state", state)
+	}
+	if request.PublishedAfter != nil {
+		query.Set("published_after", request.PublishedAfter.UTC().Format(time.RFC3339Nano))
+	}
+	if request.Descending {
+		query.Set("sort", "desc")
+	}
+
+	var messages []Message
+	header, err := client.do(ctx, retryIdempotent, http.MethodGet, "/v1/message/queue/"+url.PathEscape(request.QueueName), query, nil, &messages)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return messages, header.Get("X-Next-Cursor"), nil
+}
+
+type PurgeRequest struct {
+	QueueName   string
+	OnlyVisible bool
+	OlderThan   time.Duration
+}
+
+// RequestPurge asks for the token confirming a purge, valid until the
+// returned time. Purging needs an admin API key.
+func (client *Client) RequestPurge(ctx context.Context, request PurgeRequest) (string, time.Time, error) {
+	_, err := client.do(ctx, retryIdempotent, http.MethodPost, "/v1/queues/"+url.PathEscape(request.QueueName)+"/purge", nil, purgeInput(request, ""), nil)
+
+	var serverError *Error
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusPreconditionRequired {
+		if err == nil {
+			err = errors.New("leanqueue: purge was not asked for confirmation")
+		}
+		return "", time.Time{}, err
+	}
+
+	var confirmation struct {
+		ConfirmationToken string `json:"confirmation_token"`
+		ExpiresAt         string `json:"expires_at"`
+	}
+	if err := json.Unmarshal(serverError.body, &confirmation); err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt, err := parseTime(confirmation.ExpiresAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return confirmation.ConfirmationToken, expiresAt, nil
+}
+
+// Purge deletes the messages of a queue, confirmed by the token of
+// RequestPurge for the same request, and returns how many were deleted.
+func (client *Client) Purge(ctx context.Context, request PurgeRequest, confirmationToken string) (int64, error) {
+	var result struct {
+		Deleted int64 `json:"deleted"`
+	}
+	_, err := client.do(ctx, retryRateLimited, http.MethodPost, "/v1/queues/"+url.PathEscape(request.QueueName)+"/purge", nil, purgeInput(request, confirmationToken), &result)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Deleted, nil
+}
+
+func purgeInput(request PurgeRequest, confirmationToken string) map[string]interface{} {
+	return map[string]interface{}{
+		"only_visible":       request.OnlyVisible,
+		"older_than_seconds": int(request.OlderThan / time.Second),
+		"confirmation_token": confirmationToken,
+	}
+}
+
+type RedriveResult struct {
+	ToQueue string `json:"to_queue"`
+	Moved   int64  `json:"moved"`
+}
+
+// Redrive moves up to limit visible messages of a dead letter queue, all
+// when limit is 0, into toQueue. An empty toQueue means the only queue using
+// queueName as dead letter queue.
+func (client *Client) Redrive(ctx context.Context, queueName string, toQueue string, limit int) (*RedriveResult, error) {
+	var result RedriveResult
+	_, err := client.do(ctx, retryRateLimited, http.MethodPost, "/v1/queues/"+url.PathEscape(queueName)+"/redrive", nil, map[string]interface{}{
+		"to_queue": toQueue,
+		"limit":    limit,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Export writes to w the messages of the queues, every queue when none is
+// given, as newline-delimited JSON in the format Import reads. It is not
+// retried, and fails when the server stops before the end of the export.
+func (client *Client) Export(ctx context.Context, queueNames []string, w io.Writer) error {
+	endpoint := client.baseUrl + "/v1/queues/export"
+	if len(queueNames) > 0 {
+		endpoint += "?" + url.Values{"queue_name": queueNames}.Encode()
+	}
+
+	response, err := client.open(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(w, response.Body)
+	return err
+}
+
+type ImportResult struct {
+	Imported int64 `json:"imported"`
+	Skipped  int64 `json:"skipped"`
+}
+
+// Import stores the messages exported by Export read from r, skipping those
+// whose id is already stored. It is not retried; repeating a failed import
+// skips the messages imported before the failure.
+func (client *Client) Import(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	response, err := client.open(ctx, http.MethodPost, client.baseUrl+"/v1/queues/import", "application/x-ndjson", r)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var result ImportResult
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,278 @@
+package client_test
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureRepositoryTest "lean-queue/src/infrastructure/repositorytest"
+	InfrastructureRoutes "lean-queue/src/infrastructure/routes"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testApiKey = "test-key"
+
+// memoryRepository keeps the messages of the test server in memory. Only
+// the methods the client tests reach are implemented; the others fail.
+type memoryRepository struct {
+	InfrastructureRepositoryTest.UnimplementedRepository
+
+	mutex          sync.Mutex
+	messages       []DomainEntities.QueueEntity
+	deduplications map[string]string
+	completed      []string
+	extensions     map[string]int
+	apiKeys        map[string]DomainEntities.ApiKeyEntity
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		deduplications: map[string]string{},
+		extensions:     map[string]int{},
+		apiKeys:        map[string]DomainEntities.ApiKeyEntity{},
+	}
+}
+
+func (repository *memoryRepository) GetConfig(ctx context.Context, queueName DomainEntities.QueueNameEntity) (*DomainEntities.QueueConfigEntity, error) {
+	return DomainEntities.NewQueueConfig(queueName, 0, 0, 0, nil, nil, 0, 0, "", 0, false, 0, false)
+}
+
+func (repository *memoryRepository) Save(ctx context.Context, tenant DomainEntities.TenantEntity, message DomainEntities.QueueEntity) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.messages = append(repository.messages, message)
+	return nil
+}
+
+func (repository *memoryRepository) SaveMany(ctx context.Context, tenant DomainEntities.TenantEntity, messages []DomainEntities.QueueEntity) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.messages = append(repository.messages, messages...)
+	return nil
+}
+
+func (repository *memoryRepository) SaveDeduplicated(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	message DomainEntities.QueueEntity,
+	deduplicationId DomainEntities.DeduplicationIdEntity,
+	now time.Time,
+	expiresAt time.Time,
+) (string, bool, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	key := message.GetName().GetValue() + "/" + deduplicationId.GetValue()
+	if originalId, ok := repository.deduplications[key]; ok {
+		return originalId, true, nil
+	}
+
+	repository.deduplications[key] = message.GetId()
+	repository.messages = append(repository.messages, message)
+	return message.GetId(), false, nil
+}
+
+func (repository *memoryRepository) GetAndReserveMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	limit int,
+	filters []DomainEntities.AttributeFilterEntity,
+	messagesBefore time.Time,
+	updateReservedAt time.Time,
+	updateReservedBy string,
+	updateReservedInfo *string,
+	updateReservedExpires *time.Time,
+) ([]DomainEntities.QueueEntity, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	reserved := []DomainEntities.QueueEntity{}
+	for i, message := range repository.messages {
+		if len(reserved) == limit {
+			break
+		}
+
+		if message.GetName() != queueName || !message.GetReserveExpires().Before(messagesBefore) {
+			continue
+		}
+
+		matches := true
+		for _, filter := range filters {
+			matches = matches && filter.Matches(message.GetAttributes())
+		}
+		if !matches {
+			continue
+		}
+
+		reservedBy := updateReservedBy
+		reservedCount := 1
+		if message.GetReservedCount() != nil {
+			reservedCount += *message.GetReservedCount()
+		}
+
+		repository.messages[i] = *DomainEntities.RestoreQueue(
+			message.GetId(),
+			message.GetName(),
+			message.GetMessage(),
+			message.GetAttributes(),
+			message.GetGroupId(),
+			message.GetExpiresAt(),
+			message.GetPublishedAt(),
+			&updateReservedAt,
+			&reservedBy,
+			&reservedCount,
+			updateReservedInfo,
+			*updateReservedExpires,
+			message.GetTraceparent(),
+		)
+		reserved = append(reserved, repository.messages[i])
+	}
+
+	return reserved, nil
+}
+
+func (repository *memoryRepository) ReleaseMessage(ctx context.Context, tenant string, id string, reservedBy string, visibleAt time.Time) (bool, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	for i, message := range repository.messages {
+		if message.GetId() != id || message.GetReservedBy() == nil || *message.GetReservedBy() != reservedBy {
+			continue
+		}
+
+		repository.messages[i] = *DomainEntities.RestoreQueue(
+			message.GetId(),
+			message.GetName(),
+			message.GetMessage(),
+			message.GetAttributes(),
+			message.GetGroupId(),
+			message.GetExpiresAt(),
+			message.GetPublishedAt(),
+			message.GetReservedAt(),
+			message.GetReservedBy(),
+			message.GetReservedCount(),
+			message.GetReservedInfo(),
+			visibleAt,
+			message.GetTraceparent(),
+		)
+		repository.extensions[id]++
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (repository *memoryRepository) CompleteById(ctx context.Context, tenant string, id string, completedBy *string, completedAt time.Time) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	for i, message := range repository.messages {
+		if message.GetId() == id {
+			repository.messages = append(repository.messages[:i], repository.messages[i+1:]...)
+			repository.completed = append(repository.completed, id)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (repository *memoryRepository) ListApiKeys(ctx context.Context) ([]DomainEntities.ApiKeyEntity, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	apiKeys := []DomainEntities.ApiKeyEntity{}
+	for _, apiKey := range repository.apiKeys {
+		apiKeys = append(apiKeys, apiKey)
+	}
+	sort.Slice(apiKeys, func(i, j int) bool { return apiKeys[i].GetName() < apiKeys[j].GetName() })
+
+	return apiKeys, nil
+}
+
+func (repository *memoryRepository) GetApiKeyByHash(ctx context.Context, keyHash string) (*DomainEntities.ApiKeyEntity, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	for _, apiKey := range repository.apiKeys {
+		if apiKey.GetKeyHash() == keyHash {
+			return &apiKey, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (repository *memoryRepository) SaveApiKey(ctx context.Context, apiKey DomainEntities.ApiKeyEntity) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	if _, ok := repository.apiKeys[apiKey.GetName()]; ok {
+		return DomainEntities.NewDomainError(DomainEntities.ErrorKindConflict, "already_exists", "already exists")
+	}
+
+	repository.apiKeys[apiKey.GetName()] = apiKey
+	return nil
+}
+
+func (repository *memoryRepository) RemoveApiKey(ctx context.Context, name string) (bool, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	_, ok := repository.apiKeys[name]
+	delete(repository.apiKeys, name)
+	return ok, nil
+}
+
+func (repository *memoryRepository) stored() int {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	return len(repository.messages)
+}
+
+func (repository *memoryRepository) completedIds() []string {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	return append([]string(nil), repository.completed...)
+}
+
+func (repository *memoryRepository) extensionsOf(id string) int {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	return repository.extensions[id]
+}
+
+// newTestServer serves the routes of the server over repository, passing
+// every request through intercept first when given.
+func newTestServer(t *testing.T, repository *memoryRepository, config InfrastructureRoutes.Config, intercept func(w http.ResponseWriter, r *http.Request) bool) *httptest.Server {
+	t.Helper()
+
+	config.TenantsByApiKey = map[string]DomainEntities.TenantEntity{testApiKey: {}}
+	router := InfrastructureRoutes.NewRouter(repository, config)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if intercept != nil && intercept(w, r) {
+			return
+		}
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// rateLimited limits publishes to one per second per API key.
+var rateLimited = InfrastructureRoutes.Config{
+	PublishRateLimit: InfrastructureMiddlewares.RateLimitConfig{
+		ApiKey: InfrastructureMiddlewares.RateLimitRule{Rate: 1, Burst: 1},
+	},
+}
@@ -0,0 +1,674 @@
+// Command leanq operates a LeanQueue server through its HTTP API.
+//
+// The server and API key come from the -url and -api-key flags or the
+// LEANQ_URL and LEANQ_API_KEY environment variables. Results are printed as
+// a table or, with -output json, as JSON.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"lean-queue/client"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+const usage = `Usage: leanq <command> [flags] [arguments]
+
+Commands:
+  publish   publish a message read from files or stdin
+  reserve   reserve messages and print them
+  tail      print the messages published to a queue as they arrive
+  queues    list the queues and their counters
+  stats     print the counters of a queue
+  purge     delete the messages of a queue (admin key)
+  redrive   move the messages of a dead letter queue back
+  export    write the messages of queues as newline-delimited JSON
+  import    store the messages written by export
+  apikey    list, add or revoke the API keys stored by the server (admin key)
+
+Run "leanq <command> -h" for the flags of a command.
+`
+
+// stdout receives the results of the commands.
+var stdout io.Writer = os.Stdout
+
+// options are the flags shared by every command.
+type options struct {
+	url    string
+	apiKey string
+	output string
+}
+
+func (o *options) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.url, "url", envOr("LEANQ_URL", "http://localhost:8080"), "server URL (LEANQ_URL)")
+	flags.StringVar(&o.apiKey, "api-key", os.Getenv("LEANQ_API_KEY"), "API key (LEANQ_API_KEY)")
+	flags.StringVar(&o.output, "output", envOr("LEANQ_OUTPUT", "table"), "output format, table or json (LEANQ_OUTPUT)")
+}
+
+func (o *options) client() *client.Client {
+	return client.New(o.url, o.apiKey)
+}
+
+type command func(ctx context.Context, arguments []string) error
+
+func main() {
+	commands := map[string]command{
+		"publish": publishCommand,
+		"reserve": reserveCommand,
+		"tail":    tailCommand,
+		"queues":  queuesCommand,
+		"stats":   statsCommand,
+		"purge":   purgeCommand,
+		"redrive": redriveCommand,
+		"export":  exportCommand,
+		"import":  importCommand,
+		"apikey":  apiKeyCommand,
+	}
+
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	if err := commands[os.Args[1]](ctx, os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "leanq:", err)
+		os.Exit(1)
+	}
+}
+
+func publishCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("publish", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: leanq publish -queue <name> [flags] [file ...]\n\nPublishes every file, or stdin without files, as a message.")
+		flags.PrintDefaults()
+	}
+	o.register(flags)
+	queueName := flags.String("queue", "", "queue to publish to")
+	lines := flags.Bool("lines", false, "publish every line of the input as a message")
+	groupId := flags.String("group", "", "FIFO group of the messages")
+	ttl := flags.Duration("ttl", 0, "time to live of the messages")
+	deduplicationId := flags.String("dedup", "", "deduplication id, only with a single message")
+	attributes := attributesFlag{}
+	flags.Var(attributes, "attr", "attribute name=value, repeatable")
+	flags.Parse(arguments)
+
+	if *queueName == "" {
+		return errors.New("-queue is required")
+	}
+
+	var bodies []string
+	inputs := flags.Args()
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+	for _, input := range inputs {
+		read, err := readInput(input, *lines)
+		if err != nil {
+			return err
+		}
+		bodies = append(bodies, read...)
+	}
+
+	if *deduplicationId != "" && len(bodies) > 1 {
+		return errors.New("-dedup needs a single message")
+	}
+
+	queueClient := o.client()
+	results := []client.PublishResult{}
+	var err error
+	if *deduplicationId != "" {
+		var result *client.PublishResult
+		result, err = queueClient.Publish(ctx, client.PublishRequest{
+			QueueName:       *queueName,
+			Message:         bodies[0],
+			Attributes:      attributes,
+			GroupId:         *groupId,
+			Ttl:             *ttl,
+			DeduplicationId: *deduplicationId,
+		})
+		if err == nil {
+			results = append(results, *result)
+		}
+	}
+
+	// Without deduplication the messages go in batches, each stored whole
+	// or not at all
+	for start := 0; *deduplicationId == "" && start < len(bodies) && err == nil; start += client.MaxBatchMessages {
+		end := min(start+client.MaxBatchMessages, len(bodies))
+		messages := make([]client.BatchMessage, end-start)
+		for i, body := range bodies[start:end] {
+			messages[i] = client.BatchMessage{
+				Message:    body,
+				Attributes: attributes,
+				GroupId:    *groupId,
+				Ttl:        *ttl,
+			}
+		}
+
+		var messageIds []string
+		messageIds, err = queueClient.PublishBatch(ctx, *queueName, messages)
+		for _, messageId := range messageIds {
+			results = append(results, client.PublishResult{MessageId: messageId})
+		}
+	}
+
+	printErr := write(o.output, results, []string{"MESSAGE ID", "DEDUPLICATED"}, func(result client.PublishResult) []string {
+		return []string{result.MessageId, strconv.FormatBool(result.Deduplicated)}
+	})
+	if err != nil {
+		return fmt.Errorf("published %d of %d messages: %w", len(results), len(bodies), err)
+	}
+
+	return printErr
+}
+
+func reserveCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("reserve", flag.ExitOnError)
+	o.register(flags)
+	queueName := flags.String("queue", "", "queue to reserve from")
+	reservedBy := flags.String("reserved-by", "leanq", "consumer holding the reservation")
+	limit := flags.Int("limit", 1, "most messages to reserve")
+	reserveFor := flags.Duration("reserve-for", 60*time.Second, "how long the messages stay reserved")
+	ack := flags.Bool("ack", false, "remove the messages once printed")
+	filters := &stringsFlag{}
+	flags.Var(filters, "filter", "attribute filter such as type=order, repeatable")
+	flags.Parse(arguments)
+
+	if *queueName == "" {
+		return errors.New("-queue is required")
+	}
+
+	queueClient := o.client()
+	messages, err := queueClient.Reserve(ctx, client.ReserveRequest{
+		QueueName:  *queueName,
+		ReservedBy: *reservedBy,
+		Limit:      *limit,
+		ReserveFor: *reserveFor,
+		Filters:    *filters,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := printMessages(o.output, messages); err != nil {
+		return err
+	}
+
+	if *ack {
+		for _, message := range messages {
+			if err := queueClient.Ack(ctx, message.Id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func tailCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("tail", flag.ExitOnError)
+	o.register(flags)
+	queueName := flags.String("queue", "", "queue to follow")
+	since := flags.Duration("since", 0, "also print the messages published this long ago")
+	interval := flags.Duration("interval", time.Second, "how often to look for new messages")
+	flags.Parse(arguments)
+
+	if *queueName == "" {
+		return errors.New("-queue is required")
+	}
+
+	queueClient := o.client()
+	after := time.Now().Add(-*since)
+	// Messages published in the same microsecond as the last one printed
+	// come back with the next page, so they are remembered to skip them
+	seen := map[string]bool{}
+
+	for ctx.Err() == nil {
+		messages, _, err := queueClient.ListMessages(ctx, client.ListRequest{
+			QueueName:      *queueName,
+			Limit:          100,
+			PublishedAfter: &after,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+
+		var fresh []client.Message
+		for _, message := range messages {
+			if !seen[message.Id] {
+				fresh = append(fresh, message)
+			}
+		}
+
+		if len(fresh) > 0 {
+			if err := printMessages(o.output, fresh); err != nil {
+				return err
+			}
+		}
+
+		if len(messages) > 0 {
+			if last := messages[len(messages)-1].PublishedAt; last.After(after) {
+				after = last
+				seen = map[string]bool{}
+			}
+			for _, message := range messages {
+				if message.PublishedAt.Equal(after) {
+					seen[message.Id] = true
+				}
+			}
+		}
+
+		if len(messages) < 100 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(*interval):
+			}
+		}
+	}
+
+	return nil
+}
+
+func queuesCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("queues", flag.ExitOnError)
+	o.register(flags)
+	flags.Parse(arguments)
+
+	stats, err := o.client().ListQueues(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printStats(o.output, stats)
+}
+
+func statsCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	o.register(flags)
+	queueName := flags.String("queue", "", "queue to describe")
+	flags.Parse(arguments)
+
+	if *queueName == "" {
+		return errors.New("-queue is required")
+	}
+
+	stats, err := o.client().Stats(ctx, *queueName)
+	if err != nil {
+		return err
+	}
+
+	return printStats(o.output, []client.QueueStats{*stats})
+}
+
+func purgeCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("purge", flag.ExitOnError)
+	o.register(flags)
+	queueName := flags.String("queue", "", "queue to purge")
+	onlyVisible := flags.Bool("only-visible", false, "keep the reserved messages")
+	olderThan := flags.Duration("older-than", 0, "only messages published longer ago than this")
+	yes := flags.Bool("yes", false, "do not ask for confirmation")
+	flags.Parse(arguments)
+
+	if *queueName == "" {
+		return errors.New("-queue is required")
+	}
+
+	request := client.PurgeRequest{
+		QueueName:   *queueName,
+		OnlyVisible: *onlyVisible,
+		OlderThan:   *olderThan,
+	}
+
+	queueClient := o.client()
+	token, _, err := queueClient.RequestPurge(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if !*yes {
+		fmt.Fprintf(os.Stderr, "Purge queue %q? Type the queue name to confirm: ", *queueName)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != *queueName {
+			return errors.New("purge cancelled")
+		}
+	}
+
+	deleted, err := queueClient.Purge(ctx, request, token)
+	if err != nil {
+		return err
+	}
+
+	return write(o.output, []map[string]interface{}{{"queue_name": *queueName, "deleted": deleted}}, []string{"QUEUE", "DELETED"}, func(row map[string]interface{}) []string {
+		return []string{*queueName, strconv.FormatInt(deleted, 10)}
+	})
+}
+
+func redriveCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("redrive", flag.ExitOnError)
+	o.register(flags)
+	queueName := flags.String("queue", "", "dead letter queue to redrive")
+	toQueue := flags.String("to", "", "queue receiving the messages, by default the one using -queue as dead letter queue")
+	limit := flags.Int("limit", 0, "most messages to move, all when 0")
+	flags.Parse(arguments)
+
+	if *queueName == "" {
+		return errors.New("-queue is required")
+	}
+
+	result, err := o.client().Redrive(ctx, *queueName, *toQueue, *limit)
+	if err != nil {
+		return err
+	}
+
+	return write(o.output, []client.RedriveResult{*result}, []string{"QUEUE", "TO QUEUE", "MOVED"}, func(result client.RedriveResult) []string {
+		return []string{*queueName, result.ToQueue, strconv.FormatInt(result.Moved, 10)}
+	})
+}
+
+func exportCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: leanq export [-queue <name> ...] [-file <path>]\n\nWrites the messages of the queues, every queue without -queue, one JSON per line.")
+		flags.PrintDefaults()
+	}
+	o.register(flags)
+	var queueNames stringsFlag
+	flags.Var(&queueNames, "queue", "queue to export, repeatable")
+	path := flags.String("file", "-", "file to write, stdout when -")
+	flags.Parse(arguments)
+
+	if *path == "-" {
+		return o.client().Export(ctx, queueNames, stdout)
+	}
+
+	file, err := os.Create(*path)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	err = o.client().Export(ctx, queueNames, writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	// A partial export must not pass for a complete one
+	if err != nil {
+		os.Remove(*path)
+	}
+
+	return err
+}
+
+func importCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: leanq import [file ...]\n\nStores the messages of every file, or stdin without files, written by export.\nMessages already stored are skipped, so a failed import can be repeated.")
+		flags.PrintDefaults()
+	}
+	o.register(flags)
+	flags.Parse(arguments)
+
+	inputs := flags.Args()
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+
+	type importRow struct {
+		File     string `json:"file"`
+		Imported int64  `json:"imported"`
+		Skipped  int64  `json:"skipped"`
+	}
+
+	var rows []importRow
+	for _, input := range inputs {
+		var reader io.Reader = os.Stdin
+		if input != "-" {
+			file, err := os.Open(input)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			reader = file
+		}
+
+		result, err := o.client().Import(ctx, reader)
+		var serverError *client.Error
+		if errors.As(err, &serverError) && serverError.Details["line"] != nil {
+			return fmt.Errorf("%s:%v: %w (%v imported, %v skipped before)", input, serverError.Details["line"], err, serverError.Details["imported"], serverError.Details["skipped"])
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+		rows = append(rows, importRow{File: input, Imported: result.Imported, Skipped: result.Skipped})
+	}
+
+	return write(o.output, rows, []string{"FILE", "IMPORTED", "SKIPPED"}, func(row importRow) []string {
+		return []string{row.File, strconv.FormatInt(row.Imported, 10), strconv.FormatInt(row.Skipped, 10)}
+	})
+}
+
+// apiKeyCommand manages the API keys stored by the server, with an admin
+// key. The keys of the server config file are neither listed nor revoked.
+func apiKeyCommand(ctx context.Context, arguments []string) error {
+	var o options
+	flags := flag.NewFlagSet("apikey", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: leanq apikey list|add|revoke [flags]\n\n"+
+			"list    prints the stored API keys\n"+
+			"add     creates a key, printing it once: -name, -tenant and -admin\n"+
+			"revoke  removes the key named -name, which works for a few more seconds")
+		flags.PrintDefaults()
+	}
+	o.register(flags)
+	name := flags.String("name", "", "name of the key")
+	tenant := flags.String("tenant", "", "tenant the key operates on, the default tenant when empty")
+	admin := flags.Bool("admin", false, "allow the key to run admin operations")
+
+	if len(arguments) == 0 {
+		flags.Usage()
+		return errors.New("missing apikey command")
+	}
+	action := arguments[0]
+	flags.Parse(arguments[1:])
+
+	headers := []string{"NAME", "TENANT", "ADMIN", "CREATED AT"}
+	columns := func(apiKey client.ApiKey) []string {
+		return []string{apiKey.Name, apiKey.Tenant, strconv.FormatBool(apiKey.Admin), apiKey.CreatedAt.Format(time.RFC3339)}
+	}
+
+	switch action {
+	case "list":
+		apiKeys, err := o.client().ListApiKeys(ctx)
+		if err != nil {
+			return err
+		}
+		return write(o.output, apiKeys, headers, columns)
+
+	case "add":
+		if *name == "" {
+			return errors.New("-name is required")
+		}
+		apiKey, err := o.client().CreateApiKey(ctx, *name, *tenant, *admin)
+		if err != nil {
+			return err
+		}
+		return write(o.output, []client.ApiKey{*apiKey}, append(headers, "KEY"), func(apiKey client.ApiKey) []string {
+			return append(columns(apiKey), apiKey.Key)
+		})
+
+	case "revoke":
+		if *name == "" {
+			return errors.New("-name is required")
+		}
+		return o.client().RevokeApiKey(ctx, *name)
+	}
+
+	flags.Usage()
+	return fmt.Errorf("unknown apikey command %q", action)
+}
+
+func readInput(name string, lines bool) ([]string, error) {
+	var reader io.Reader = os.Stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	if !lines {
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(content)}, nil
+	}
+
+	var messages []string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			messages = append(messages, line)
+		}
+	}
+
+	return messages, scanner.Err()
+}
+
+func printMessages(output string, messages []client.Message) error {
+	return write(output, messages, []string{"ID", "PUBLISHED AT", "RECEIVES", "ATTRIBUTES", "MESSAGE"}, func(message client.Message) []string {
+		attributes := make([]string, 0, len(message.Attributes))
+		for name, value := range message.Attributes {
+			attributes = append(attributes, name+"="+value)
+		}
+		return []string{
+			message.Id,
+			message.PublishedAt.Format(time.RFC3339),
+			strconv.Itoa(message.ReservedCount),
+			strings.Join(attributes, ","),
+			abbreviate(message.Message, 60),
+		}
+	})
+}
+
+func printStats(output string, stats []client.QueueStats) error {
+	return write(output, stats, []string{"QUEUE", "MESSAGES", "VISIBLE", "IN FLIGHT", "DELAYED", "BYTES", "OLDEST", "PAUSED"}, func(stats client.QueueStats) []string {
+		oldest := "-"
+		if stats.OldestPublishedAt != nil {
+			oldest = time.Since(*stats.OldestPublishedAt).Round(time.Second).String()
+		}
+		return []string{
+			stats.QueueName,
+			strconv.FormatInt(stats.Messages, 10),
+			strconv.FormatInt(stats.Visible, 10),
+			strconv.FormatInt(stats.InFlight, 10),
+			strconv.FormatInt(stats.Delayed, 10),
+			strconv.FormatInt(stats.Bytes, 10),
+			oldest,
+			strconv.FormatBool(stats.Paused),
+		}
+	})
+}
+
+// write writes rows as JSON lines or as a table of headers and the columns
+// returned by columns.
+func write[T any](output string, rows []T, headers []string, columns func(T) []string) error {
+	if output == "json" {
+		encoder := json.NewEncoder(stdout)
+		for _, row := range rows {
+			if err := encoder.Encode(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if output != "table" {
+		return fmt.Errorf("unknown output %q, use table or json", output)
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(columns(row), "\t"))
+	}
+
+	return writer.Flush()
+}
+
+func abbreviate(value string, length int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if len([]rune(value)) <= length {
+		return value
+	}
+
+	return string([]rune(value)[:length-1]) + "…"
+}
+
+func envOr(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+type attributesFlag map[string]string
+
+func (a attributesFlag) String() string {
+	return ""
+}
+
+func (a attributesFlag) Set(value string) error {
+	name, attributeValue, found := strings.Cut(value, "=")
+	if !found || name == "" {
+		return errors.New("attributes are name=value")
+	}
+
+	a[name] = attributeValue
+	return nil
+}
+
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// captureStdout runs fn with stdout written to the returned buffer.
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+
+	var output bytes.Buffer
+	previous := stdout
+	stdout = &output
+	t.Cleanup(func() {
+		stdout = previous
+	})
+
+	err := fn()
+	return output.String(), err
+}
+
+type testRow struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func writeTestRows(output string) func() error {
+	rows := []testRow{{Name: "orders", Count: 3}, {Name: "orders-dlq", Count: 12}}
+	return func() error {
+		return write(output, rows, []string{"NAME", "COUNT"}, func(row testRow) []string {
+			return []string{row.Name, strings.Repeat("#", row.Count)}
+		})
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	output, err := captureStdout(t, writeTestRows("table"))
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	want := "NAME        COUNT\n" +
+		"orders      ###\n" +
+		"orders-dlq  ############\n"
+	if output != want {
+		t.Errorf("table =\n%s\nwant\n%s", output, want)
+	}
+}
+
+func TestWriteJson(t *testing.T) {
+	output, err := captureStdout(t, writeTestRows("json"))
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	want := `{"name":"orders","count":3}` + "\n" + `{"name":"orders-dlq","count":12}` + "\n"
+	if output != want {
+		t.Errorf("json = %q, want one object per line %q", output, want)
+	}
+}
+
+func TestWriteRejectsUnknownOutput(t *testing.T) {
+	output, err := captureStdout(t, writeTestRows("yaml"))
+	if err == nil || !strings.Contains(err.Error(), `unknown output "yaml"`) {
+		t.Errorf("write = %v, want an unknown output error", err)
+	}
+	if output != "" {
+		t.Errorf("write printed %q for an unknown output", output)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	attributes := attributesFlag{}
+	for _, value := range []string{"type=order", "empty=", "url=https://example.com/?a=b"} {
+		if err := attributes.Set(value); err != nil {
+			t.Errorf("-attr %s failed: %v", value, err)
+		}
+	}
+	if attributes["type"] != "order" || attributes["empty"] != "" || attributes["url"] != "https://example.com/?a=b" {
+		t.Errorf("attributes = %v, want split at the first =", attributes)
+	}
+	for _, value := range []string{"type", "=order"} {
+		if err := attributes.Set(value); err == nil {
+			t.Errorf("-attr %s was accepted", value)
+		}
+	}
+
+	var filters stringsFlag
+	filters.Set("type=order")
+	filters.Set("!urgent")
+	if filters.String() != "type=order,!urgent" {
+		t.Errorf("filters = %q, want both in order", filters.String())
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "short", want: "short"},
+		{value: "one\n  two", want: "one two"},
+		{value: "ação repetida", want: "ação rep…"},
+	}
+
+	for _, test := range tests {
+		if got := abbreviate(test.value, 9); got != test.want {
+			t.Errorf("abbreviate(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
+
+func TestCommandsRequireTheirFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   command
+		arguments []string
+		want      string
+	}{
+		{name: "publish", command: publishCommand, arguments: []string{"-attr", "type=order"}, want: "-queue is required"},
+		{name: "reserve", command: reserveCommand, arguments: []string{"-limit", "5"}, want: "-queue is required"},
+		{name: "stats", command: statsCommand, arguments: []string{}, want: "-queue is required"},
+		{name: "redrive", command: redriveCommand, arguments: []string{"-to", "orders"}, want: "-queue is required"},
+		{name: "apikey", command: apiKeyCommand, arguments: []string{}, want: "missing apikey command"},
+		{name: "apikey add", command: apiKeyCommand, arguments: []string{"add", "-tenant", "acme"}, want: "-name is required"},
+		{name: "apikey revoke", command: apiKeyCommand, arguments: []string{"revoke"}, want: "-name is required"},
+		{name: "apikey rotate", command: apiKeyCommand, arguments: []string{"rotate", "-name", "ci"}, want: `unknown apikey command "rotate"`},
+	}
+
+	for _, test := range tests {
+		_, err := captureStdout(t, func() error {
+			return test.command(context.Background(), test.arguments)
+		})
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%s = %v, want %q", test.name, err, test.want)
+		}
+	}
+}
+
+func TestApiKeyAdd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/api-keys" || r.Header.Get("ApiAuthorization") != "admin-key" {
+			t.Errorf("request %s %s with key %q, want POST /v1/api-keys with admin-key", r.Method, r.URL.Path, r.Header.Get("ApiAuthorization"))
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "ci" || body["tenant"] != "acme" || body["admin"] != false {
+			t.Errorf("body = %v, want ci of acme without admin", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"ci","tenant":"acme","admin":false,"created_at":"2026-10-19 08:30:00","key":"secret"}`))
+	}))
+	defer server.Close()
+
+	arguments := []string{"add", "-url", server.URL, "-api-key", "admin-key", "-name", "ci", "-tenant", "acme"}
+
+	output, err := captureStdout(t, func() error {
+		return apiKeyCommand(context.Background(), append(arguments, "-output", "table"))
+	})
+	if err != nil {
+		t.Fatalf("apikey add failed: %v", err)
+	}
+	want := "NAME  TENANT  ADMIN  CREATED AT            KEY\n" +
+		"ci    acme    false  2026-10-19T08:30:00Z  secret\n"
+	if output != want {
+		t.Errorf("table =\n%s\nwant\n%s", output, want)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return apiKeyCommand(context.Background(), append(arguments, "-output", "json"))
+	})
+	if err != nil {
+		t.Fatalf("apikey add failed: %v", err)
+	}
+	want = `{"name":"ci","tenant":"acme","admin":false,"created_at":"2026-10-19T08:30:00Z","key":"secret"}` + "\n"
+	if output != want {
+		t.Errorf("json = %q, want %q", output, want)
+	}
+}
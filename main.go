@@ -1,41 +1,46 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
-	"io"
-	InfrastructureControllers "lean-queue/src/infrastructure/controllers"
+	DomainEntities "lean-queue/src/domain/entities"
+	InfrastructureDocs "lean-queue/src/infrastructure/docs"
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
 	InfrastructureRepositories "lean-queue/src/infrastructure/repositories"
-	"log"
+	InfrastructureRoutes "lean-queue/src/infrastructure/routes"
+	InfrastructureTracing "lean-queue/src/infrastructure/tracing"
+	InfrastructureWorkers "lean-queue/src/infrastructure/workers"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/http/fcgi"
 	"os"
+	"os/signal"
 	"runtime"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
+	// Schedule time zones must resolve even on hosts without a zoneinfo database
+	_ "time/tzdata"
 
-	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 	"github.com/spf13/viper"
 )
 
 func main() {
-	currentFormattedTime := time.Now().Format("2006-01")
-	logFile, err := os.Create("log-main[" + currentFormattedTime + "].log")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer logFile.Close()
-
-	multiWriter := io.MultiWriter(logFile, os.Stdout)
-	log.SetOutput(multiWriter)
-	log.Println("Iniciando...")
+	slog.Info("Iniciando...")
 	safeGoRoutine(run)
+	slog.Info("Finalizado.")
 }
 
 func run() {
 	defer func() {
 		if err := recover(); err != nil {
-			log.Println("PANIC/ERROR interno:", err)
+			slog.Error("PANIC/ERROR interno", "error", err)
 			errStr := fmt.Sprintf("%s", err)
 			os.WriteFile("debug-LAST-ERROR.txt", []byte(errStr), 0644)
 		}
@@ -49,7 +54,7 @@ func run() {
 	viper.SetConfigType("yml")
 
 	if err := viper.ReadInConfig(); err != nil {
-		log.Printf("Error reading config file, %s", err)
+		slog.Warn("error reading config file", "error", err)
 	}
 
 	config := new(struct {
@@ -57,56 +62,116 @@ func run() {
 			Example string
 		}
 		Server struct {
-			Method  string
-			Port    string
-			ApiKeys map[string]string
+			Method             string
+			Port               string
+			ShutdownTimeout    time.Duration `mapstructure:"shutdown_timeout"`
+			ApiKeys            map[string]string
+			AdminApiKeys       []string `mapstructure:"admin_api_keys"`
+			ConfirmationSecret string   `mapstructure:"confirmation_secret"`
+			Tenants            map[string]struct {
+				ApiKeys     []string
+				MaxMessages int64 `mapstructure:"max_messages"`
+				MaxBytes    int64 `mapstructure:"max_bytes"`
+			}
+			RateLimits struct {
+				Publish rateLimitsConfig
+				Reserve rateLimitsConfig
+			}
+		}
+		Janitor struct {
+			Disabled  bool
+			Interval  time.Duration
+			BatchSize int `mapstructure:"batch_size"`
+		}
+		Push struct {
+			Disabled  bool
+			Interval  time.Duration
+			RetryBase time.Duration `mapstructure:"retry_base"`
+			RetryMax  time.Duration `mapstructure:"retry_max"`
+			// AllowedHosts are the only hosts push subscriptions may deliver
+			// to, "*.example.com" allowing its subdomains. When empty any
+			// host is allowed, including internal services reachable from
+			// the server, so set it when API keys are handed to untrusted clients
+			AllowedHosts []string `mapstructure:"allowed_hosts"`
 		}
-		URL string
+		Scheduler struct {
+			Disabled  bool
+			Interval  time.Duration
+			BatchSize int `mapstructure:"batch_size"`
+		}
+		Log     InfrastructureLogger.Config
+		Tracing InfrastructureTracing.Config
+		URL     string
 	})
 
 	viper.Unmarshal(config)
 
-	router := mux.NewRouter().StrictSlash(true)
-
-	c := cors.New(cors.Options{
-		AllowCredentials: true,
-		AllowedHeaders:   []string{"*"},
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "OPTIONS"},
-	})
-	handler := c.Handler(router)
-	router.StrictSlash(true)
+	logger, closeLogger, err := InfrastructureLogger.New(config.Log)
+	if err != nil {
+		slog.Error("invalid log config", "error", err)
+		return
+	}
+	defer closeLogger()
+	slog.SetDefault(logger)
 
-	apiV1Router := router.PathPrefix("/v1").Subrouter()
-	apiV1Router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	shutdownTracing, err := InfrastructureTracing.Setup(context.Background(), config.Tracing)
+	if err != nil {
+		slog.Error("invalid tracing config", "error", err)
+		return
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			slog.Warn("failed to flush traces", "error", err)
+		}
+	}()
 
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-			if r.Method == "OPTIONS" {
-				return
-			}
+	// Keys under server.apikeys use the default (unscoped) tenant
+	tenantsByApiKey := map[string]DomainEntities.TenantEntity{}
+	tenantsById := map[string]DomainEntities.TenantEntity{}
+	for _, apiKey := range config.Server.ApiKeys {
+		tenantsByApiKey[apiKey] = DomainEntities.TenantEntity{}
+	}
+	for tenantId, tenantConfig := range config.Server.Tenants {
+		tenant, err := DomainEntities.NewTenant(tenantId, tenantConfig.MaxMessages, tenantConfig.MaxBytes)
+		if err != nil {
+			slog.Error("invalid tenant", "tenant", tenantId, "error", err)
+			return
+		}
+		for _, apiKey := range tenantConfig.ApiKeys {
+			tenantsByApiKey[apiKey] = *tenant
+		}
+		tenantsById[tenantId] = *tenant
+	}
 
-			token := r.Header.Get("ApiAuthorization")
+	// Rate limit overrides are keyed by API key name or tenant id, never by the token itself,
+	// and apply as well to the stored API keys of that name
+	apiKeysByName := map[string][]string{}
+	for name, apiKey := range config.Server.ApiKeys {
+		apiKeysByName[name] = append(apiKeysByName[name], apiKey)
+	}
+	for tenantId, tenantConfig := range config.Server.Tenants {
+		apiKeysByName[tenantId] = append(apiKeysByName[tenantId], tenantConfig.ApiKeys...)
+	}
 
-			ok := false
-			for _, k := range config.Server.ApiKeys {
-				if k == token {
-					ok = true
-					break
-				}
-			}
+	// Admin operations are allowed to the API key names or tenant ids under server.admin_api_keys
+	adminApiKeys := map[string]bool{}
+	for _, name := range config.Server.AdminApiKeys {
+		for _, apiKey := range apiKeysByName[name] {
+			adminApiKeys[apiKey] = true
+		}
+	}
 
-			if ok {
-				next.ServeHTTP(w, r)
-			} else {
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte("Token inválido!"))
-			}
-		})
-	})
-	apiV1Router.StrictSlash(true)
+	confirmationSecret := []byte(config.Server.ConfirmationSecret)
+	if len(confirmationSecret) == 0 {
+		slog.Warn("server.confirmation_secret not set, confirmation tokens are only valid on this instance")
+		confirmationSecret = make([]byte, 32)
+		if _, err := rand.Read(confirmationSecret); err != nil {
+			slog.Error("failed to generate confirmation secret", "error", err)
+			return
+		}
+	}
 
 	repositoryQueue := InfrastructureRepositories.NewQueueRepository(
 		viper.GetString("db.host"),
@@ -115,46 +180,213 @@ func run() {
 		viper.GetString("db.password"),
 		viper.GetString("db.db_name"),
 	)
+	defer repositoryQueue.Close()
 
-	controllerPublishMessage := InfrastructureControllers.NewPublishMessageController(repositoryQueue)
-	controllerRemoveMessage := InfrastructureControllers.NewRemoveMessageController(repositoryQueue)
-	controllerGetAndReserveNextMessages := InfrastructureControllers.NewGetAndReserveNextMessagesController(repositoryQueue)
-	controllerGetMessagesOnQueue := InfrastructureControllers.NewGetMessagesOnQueueController(repositoryQueue)
-
-	apiV1Router.HandleFunc("/message", controllerPublishMessage.Handle).Methods("POST")
-	apiV1Router.HandleFunc("/message", controllerRemoveMessage.Handle).Methods("DELETE")
-	apiV1Router.HandleFunc("/message/next", controllerGetAndReserveNextMessages.Handle).Methods("GET")
-	apiV1Router.HandleFunc("/message/queue/{queue_name}", controllerGetMessagesOnQueue.Handle).Methods("GET")
-
-	router.HandleFunc(
-		"/",
-		func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK - Carregado."))
-		},
-	).Methods("GET")
-
-	router.HandleFunc("/alive", func(w http.ResponseWriter, r *http.Request) {
-		log.Println("Alive")
-		fmt.Fprintf(w, "OK")
+	// Purges, redrives, exports and imports work in batches of the janitor
+	// batch size too
+	if config.Janitor.BatchSize == 0 {
+		config.Janitor.BatchSize = 500
+	}
+
+	router := InfrastructureRoutes.NewRouter(repositoryQueue, InfrastructureRoutes.Config{
+		TenantsByApiKey:    tenantsByApiKey,
+		TenantsById:        tenantsById,
+		AdminApiKeys:       adminApiKeys,
+		PublishRateLimit:   config.Server.RateLimits.Publish.toMiddlewareConfig(apiKeysByName),
+		ReserveRateLimit:   config.Server.RateLimits.Reserve.toMiddlewareConfig(apiKeysByName),
+		ConfirmationSecret: confirmationSecret,
+		PushAllowedHosts:   config.Push.AllowedHosts,
+		BatchSize:          config.Janitor.BatchSize,
 	})
 
-	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		log.Println("Health")
-		fmt.Fprintf(w, "OK")
+	undocumentedRoutes, err := InfrastructureDocs.UndocumentedRoutes(router)
+	if err != nil {
+		slog.Warn("failed to read the OpenAPI document", "error", err)
+	}
+	for _, route := range undocumentedRoutes {
+		slog.Warn("route missing from the OpenAPI document", "route", route)
+	}
+
+	c := cors.New(cors.Options{
+		AllowCredentials: true,
+		AllowedHeaders:   []string{"*"},
+		AllowedOrigins:   []string{"*"},
+		AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "OPTIONS"},
 	})
+	handler := c.Handler(router)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	if config.Server.ShutdownTimeout == 0 {
+		config.Server.ShutdownTimeout = 30 * time.Second
+	}
+
+	// Workers stop before the deferred repository close runs
+	var workers sync.WaitGroup
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	defer func() {
+		stopWorkers()
+		workers.Wait()
+	}()
+
+	if !config.Janitor.Disabled {
+		if config.Janitor.Interval == 0 {
+			config.Janitor.Interval = 30 * time.Second
+		}
+		janitor := InfrastructureWorkers.NewJanitorWorker(repositoryQueue, config.Janitor.Interval, config.Janitor.BatchSize)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			janitor.Run(workersCtx)
+		}()
+	}
+
+	if !config.Push.Disabled {
+		if config.Push.Interval == 0 {
+			config.Push.Interval = time.Second
+		}
+		if config.Push.RetryBase == 0 {
+			config.Push.RetryBase = 5 * time.Second
+		}
+		if config.Push.RetryMax == 0 {
+			config.Push.RetryMax = 10 * time.Minute
+		}
+		push := InfrastructureWorkers.NewPushWorker(repositoryQueue, config.Push.Interval, config.Push.RetryBase, config.Push.RetryMax, config.Push.AllowedHosts)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			push.Run(workersCtx)
+		}()
+	}
+
+	if !config.Scheduler.Disabled {
+		if config.Scheduler.Interval == 0 {
+			config.Scheduler.Interval = time.Second
+		}
+		if config.Scheduler.BatchSize == 0 {
+			config.Scheduler.BatchSize = 100
+		}
+		scheduler := InfrastructureWorkers.NewSchedulerWorker(repositoryQueue, tenantsById, config.Scheduler.Interval, config.Scheduler.BatchSize)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			scheduler.Run(workersCtx)
+		}()
+	}
 
 	if config.Server.Method == "http" {
-		log.Printf("Server started at port %s\n", config.Server.Port)
+		slog.Info("server started", "port", config.Server.Port)
 		server := &http.Server{
 			Addr:         "0.0.0.0:" + config.Server.Port,
 			Handler:      handler,
 			ReadTimeout:  120 * time.Second,
 			WriteTimeout: 120 * time.Second,
 		}
-		log.Fatal(server.ListenAndServe())
+		if err := serveHttp(ctx, server, config.Server.ShutdownTimeout); err != nil {
+			slog.Error("server stopped with error", "error", err)
+		}
 	} else {
-		fcgi.Serve(nil, router)
+		if err := serveFastCgi(ctx, router, config.Server.ShutdownTimeout); err != nil {
+			slog.Error("server stopped with error", "error", err)
+		}
+	}
+}
+
+// serveHttp serves until ctx is done, then stops accepting connections and
+// waits up to timeout for in-flight requests.
+func serveHttp(ctx context.Context, server *http.Server, timeout time.Duration) error {
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutdown signal received, draining requests")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-serverErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// serveFastCgi serves on the listener inherited as stdin, the same one
+// fcgi.Serve(nil, ...) uses, so it can be closed on shutdown.
+func serveFastCgi(ctx context.Context, handler http.Handler, timeout time.Duration) error {
+	listener, err := net.FileListener(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	var inFlight sync.WaitGroup
+	trackedHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight.Add(1)
+		defer inFlight.Done()
+		handler.ServeHTTP(w, r)
+	})
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- fcgi.Serve(listener, trackedHandler)
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutdown signal received, draining requests")
+	listener.Close()
+
+	drained := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-time.After(timeout):
+		return errors.New("shutdown timeout exceeded with requests in flight")
+	}
+}
+
+type rateLimitsConfig struct {
+	ApiKey  InfrastructureMiddlewares.RateLimitRule `mapstructure:"api_key"`
+	Queue   InfrastructureMiddlewares.RateLimitRule
+	ApiKeys map[string]InfrastructureMiddlewares.RateLimitRule `mapstructure:"api_keys"`
+	Queues  map[string]InfrastructureMiddlewares.RateLimitRule
+}
+
+func (limits rateLimitsConfig) toMiddlewareConfig(apiKeysByName map[string][]string) InfrastructureMiddlewares.RateLimitConfig {
+	rulesByApiKey := map[string]InfrastructureMiddlewares.RateLimitRule{}
+	for name, rule := range limits.ApiKeys {
+		for _, apiKey := range apiKeysByName[name] {
+			rulesByApiKey[apiKey] = rule
+		}
+	}
+
+	// Queue overrides name the queues of a tenant as tenant/queue
+	return InfrastructureMiddlewares.RateLimitConfig{
+		ApiKey:        limits.ApiKey,
+		Queue:         limits.Queue,
+		ApiKeys:       rulesByApiKey,
+		StoredApiKeys: limits.ApiKeys,
+		Queues:        limits.Queues,
 	}
 }
 
@@ -167,10 +399,10 @@ func safeGoRoutine(fn func()) {
 					if strErr, ok := r.(string); ok && (strings.Contains(strErr, "pthread_create failed: Resource temporarily unavailable") ||
 						strings.Contains(strErr, "unknown pc") ||
 						strings.Contains(strErr, "failed to create new OS thread")) {
-						log.Println("Erro específico detectado, tentando novamente...")
+						slog.Warn("Erro específico detectado, tentando novamente...")
 						success <- false
 					} else {
-						log.Println("Erro diferente detectado:", r)
+						slog.Error("Erro diferente detectado", "error", r)
 						success <- true
 					}
 
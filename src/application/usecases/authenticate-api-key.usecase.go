@@ -0,0 +1,31 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type authenticateApiKeyUsecase struct {
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+}
+
+func NewAuthenticateApiKeyUsecase(
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *authenticateApiKeyUsecase {
+	return &authenticateApiKeyUsecase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+// Handle returns the stored API key matching key, or nil when there is none.
+func (usecase *authenticateApiKeyUsecase) Handle(ctx context.Context, key string) (*DomainEntities.ApiKeyEntity, error) {
+	ctx, span := tracer.Start(ctx, "AuthenticateApiKeyUsecase")
+	defer span.End()
+
+	if key == "" {
+		return nil, nil
+	}
+
+	return usecase.apiKeyRepository.GetApiKeyByHash(ctx, DomainEntities.HashApiKey(key))
+}
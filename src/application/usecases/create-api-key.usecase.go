@@ -0,0 +1,50 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type createApiKeyUsecase struct {
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+}
+
+func NewCreateApiKeyUsecase(
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *createApiKeyUsecase {
+	return &createApiKeyUsecase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+// Handle generates and stores a key named name for tenant, returning the
+// key itself alongside the stored entity: only its hash is kept, so it
+// cannot be read again.
+func (usecase *createApiKeyUsecase) Handle(
+	ctx context.Context,
+	name string,
+	tenant string,
+	admin bool,
+) (*DomainEntities.ApiKeyEntity, string, error) {
+	ctx, span := tracer.Start(ctx, "CreateApiKeyUsecase")
+	defer span.End()
+
+	key, err := DomainEntities.GenerateApiKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiKey, err := DomainEntities.NewApiKey(name, tenant, DomainEntities.HashApiKey(key), admin, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = usecase.apiKeyRepository.SaveApiKey(ctx, *apiKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return apiKey, key, nil
+}
@@ -0,0 +1,79 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type createPushSubscriptionUsecase struct {
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface
+	allowedHosts               []string
+}
+
+// NewCreatePushSubscriptionUsecase only accepts urls whose host is one of
+// allowedHosts, any host when it is empty.
+func NewCreatePushSubscriptionUsecase(
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface,
+	allowedHosts []string,
+) *createPushSubscriptionUsecase {
+	return &createPushSubscriptionUsecase{
+		pushSubscriptionRepository: pushSubscriptionRepository,
+		allowedHosts:               allowedHosts,
+	}
+}
+
+// Handle creates a push subscription for queueName. A zero maxConcurrency
+// or timeoutSeconds takes the default, and an empty secret is generated.
+func (usecase *createPushSubscriptionUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	queueName string,
+	url string,
+	secret string,
+	maxConcurrency int,
+	timeoutSeconds int,
+) (*DomainEntities.PushSubscriptionEntity, error) {
+	ctx, span := tracer.Start(ctx, "CreatePushSubscriptionUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		secretBytes := make([]byte, 32)
+		if _, err := rand.Read(secretBytes); err != nil {
+			return nil, err
+		}
+		secret = hex.EncodeToString(secretBytes)
+	}
+
+	if maxConcurrency == 0 {
+		maxConcurrency = DomainEntities.DefaultPushMaxConcurrency
+	}
+
+	if timeoutSeconds == 0 {
+		timeoutSeconds = DomainEntities.DefaultPushTimeoutSeconds
+	}
+
+	subscription, err := DomainEntities.NewPushSubscription(nil, *queueNameEntity, url, secret, maxConcurrency, timeoutSeconds, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if !subscription.IsHostAllowed(usecase.allowedHosts) {
+		return nil, DomainEntities.ErrPushHostNotAllowed
+	}
+
+	err = usecase.pushSubscriptionRepository.SavePushSubscription(ctx, *subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,219 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	"encoding/json"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"log/slog"
+	"time"
+)
+
+type enforceQueuePoliciesUsecase struct {
+	queueRepository        DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository  DomainRepositories.QueueConfigRepositoryInterface
+	queueArchiveRepository DomainRepositories.QueueArchiveRepositoryInterface
+}
+
+func NewEnforceQueuePoliciesUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	queueArchiveRepository DomainRepositories.QueueArchiveRepositoryInterface,
+) *enforceQueuePoliciesUsecase {
+	return &enforceQueuePoliciesUsecase{
+		queueRepository:        queueRepository,
+		queueConfigRepository:  queueConfigRepository,
+		queueArchiveRepository: queueArchiveRepository,
+	}
+}
+
+// Handle applies retention, max length, dead letter and archive retention
+// policies of every configured queue, expires messages past their time to live and forgets
+// expired deduplication ids, working in batches of batchSize rows.
+func (usecase *enforceQueuePoliciesUsecase) Handle(ctx context.Context, now time.Time, batchSize int) error {
+	ctx, span := tracer.Start(ctx, "EnforceQueuePoliciesUsecase")
+	defer span.End()
+
+	configs, err := usecase.queueConfigRepository.ListConfigs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, config := range configs {
+		if err := usecase.enforce(ctx, config, now, batchSize); err != nil {
+			slog.Error("failed to enforce queue policies", "queue_name", config.GetName().GetValue(), "error", err)
+		}
+	}
+
+	expiredQueues, err := usecase.queueRepository.GetQueuesWithExpiredMessages(ctx, now, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, queueName := range expiredQueues {
+		if err := usecase.expire(ctx, queueName, now, batchSize); err != nil {
+			slog.Error("failed to expire messages", "queue_name", queueName.GetValue(), "error", err)
+		}
+	}
+
+	for {
+		removed, err := usecase.queueRepository.RemoveExpiredDeduplications(ctx, now, batchSize)
+		if err != nil {
+			return err
+		}
+		if removed < int64(batchSize) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (usecase *enforceQueuePoliciesUsecase) enforce(ctx context.Context, config DomainEntities.QueueConfigEntity, now time.Time, batchSize int) error {
+
+	if config.GetRetentionSeconds() > 0 {
+		err := usecase.drain(ctx, config, "message.expired", "retention", batchSize, func() ([]string, error) {
+			return usecase.queueRepository.RemovePublishedBefore(ctx, config.GetName(), now.Add(-config.GetRetention()), batchSize)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.GetMaxLength() > 0 {
+		err := usecase.drain(ctx, config, "message.expired", "max_length", batchSize, func() ([]string, error) {
+			return usecase.queueRepository.TrimToLength(ctx, config.GetName(), config.GetMaxLength(), batchSize)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.GetMaxReceives() > 0 {
+		err := usecase.drain(ctx, config, "message.dead_lettered", "max_receives", batchSize, func() ([]string, error) {
+			return usecase.queueRepository.MoveExhaustedMessages(
+				ctx,
+				config.GetName(),
+				config.GetMaxReceives(),
+				*config.GetDeadLetterQueue(),
+				now,
+				batchSize,
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Archived messages are history, so their removal emits no events
+	if config.GetArchiveRetentionSeconds() > 0 {
+		for {
+			removed, err := usecase.queueArchiveRepository.RemoveArchivedBefore(ctx, config.GetName(), now.Add(-config.GetArchiveRetention()), batchSize)
+			if err != nil {
+				return err
+			}
+			if removed < int64(batchSize) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (usecase *enforceQueuePoliciesUsecase) expire(ctx context.Context, queueName DomainEntities.QueueNameEntity, now time.Time, batchSize int) error {
+
+	config, err := usecase.queueConfigRepository.GetConfig(ctx, queueName)
+	if err != nil {
+		return err
+	}
+
+	if config.GetExpirationPolicy() == DomainEntities.ExpirationPolicyDeadLetter {
+		return usecase.drain(ctx, *config, "message.dead_lettered", "ttl", batchSize, func() ([]string, error) {
+			return usecase.queueRepository.MoveExpiredMessages(ctx, queueName, *config.GetDeadLetterQueue(), now, batchSize)
+		})
+	}
+
+	return usecase.drain(ctx, *config, "message.expired", "ttl", batchSize, func() ([]string, error) {
+		return usecase.queueRepository.RemoveExpired(ctx, queueName, now, batchSize)
+	})
+}
+
+// drain repeats step until it handles less than a full batch, emitting an
+// event for every affected message.
+func (usecase *enforceQueuePoliciesUsecase) drain(
+	ctx context.Context,
+	config DomainEntities.QueueConfigEntity,
+	event string,
+	reason string,
+	batchSize int,
+	step func() ([]string, error),
+) error {
+	for {
+		messageIds, err := step()
+		if err != nil {
+			return err
+		}
+
+		for _, messageId := range messageIds {
+			usecase.emit(ctx, config, event, reason, messageId)
+		}
+
+		if len(messageIds) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (usecase *enforceQueuePoliciesUsecase) emit(ctx context.Context, config DomainEntities.QueueConfigEntity, event string, reason string, messageId string) {
+	emitQueueEvent(ctx, usecase.queueRepository, config, event, reason, messageId)
+}
+
+// emitQueueEvent logs event and, when the queue has an events queue,
+// publishes it there.
+func emitQueueEvent(
+	ctx context.Context,
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	config DomainEntities.QueueConfigEntity,
+	event string,
+	reason string,
+	messageId string,
+) {
+	slog.Info("queue event", "event", event, "reason", reason, "queue_name", config.GetName().GetValue(), "message_id", messageId)
+
+	if config.GetEventsQueue() == nil {
+		return
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"event":       event,
+		"reason":      reason,
+		"queue_name":  config.GetName().GetValue(),
+		"message_id":  messageId,
+		"occurred_at": time.Now().UTC().Format("2006-01-02 15:04:05.999999"),
+	})
+
+	messageEntity, err := DomainEntities.NewQueueMessage(string(payload))
+	if err != nil {
+		slog.Error("failed to emit queue event", "event", event, "error", err)
+		return
+	}
+
+	queueEntity, err := DomainEntities.NewQueue(nil, *config.GetEventsQueue(), *messageEntity, DomainEntities.MessageAttributesEntity{}, nil, nil, time.Now(), nil, nil, nil, nil, time.Now(), nil)
+	if err != nil {
+		slog.Error("failed to emit queue event", "event", event, "error", err)
+		return
+	}
+
+	// Events are stored whatever the tenant quota, so that a tenant over it
+	// still learns what the janitor did
+	tenant, err := DomainEntities.NewTenant(config.GetName().GetTenant(), 0, 0)
+	if err != nil {
+		slog.Error("failed to emit queue event", "event", event, "error", err)
+		return
+	}
+
+	if err := queueRepository.Save(ctx, *tenant, *queueEntity); err != nil {
+		slog.Error("failed to emit queue event", "event", event, "error", err)
+	}
+}
@@ -0,0 +1,106 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type exportQueuesUsecase struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+	batchSize       int
+}
+
+// NewExportQueuesUsecase reads batchSize messages per query, so exporting a
+// queue never holds more than that many messages in memory.
+func NewExportQueuesUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	batchSize int,
+) *exportQueuesUsecase {
+	return &exportQueuesUsecase{
+		queueRepository: queueRepository,
+		batchSize:       batchSize,
+	}
+}
+
+// Handle passes every message of the queues, every queue of the tenant when
+// queueNames is empty, to emit in publish order, queue after queue. It stops
+// at the first error of emit and returns it.
+func (usecase *exportQueuesUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	queueNames []string,
+	emit func(message DomainEntities.QueueEntity) error,
+) error {
+	ctx, span := tracer.Start(ctx, "ExportQueuesUsecase")
+	defer span.End()
+
+	queueNameEntities, err := usecase.queues(ctx, tenant, queueNames)
+	if err != nil {
+		return err
+	}
+
+	filter, err := DomainEntities.NewMessageListFilter(nil, nil, nil, nil, 0, false)
+	if err != nil {
+		return err
+	}
+
+	for _, queueNameEntity := range queueNameEntities {
+		var cursor *DomainEntities.MessageCursorEntity
+		for {
+			messages, err := usecase.queueRepository.GetMessages(ctx, queueNameEntity, *filter, cursor, usecase.batchSize, time.Now())
+			if err != nil {
+				return err
+			}
+
+			for _, message := range messages {
+				if err := emit(message); err != nil {
+					return err
+				}
+			}
+
+			if len(messages) < usecase.batchSize {
+				break
+			}
+
+			last := messages[len(messages)-1]
+			cursor = DomainEntities.NewMessageCursor(last.GetPublishedAt(), last.GetId())
+		}
+	}
+
+	return nil
+}
+
+func (usecase *exportQueuesUsecase) queues(ctx context.Context, tenant DomainEntities.TenantEntity, queueNames []string) ([]DomainEntities.QueueNameEntity, error) {
+	if len(queueNames) == 0 {
+		stats, err := usecase.queueRepository.ListQueueStats(ctx, tenant.GetId(), time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		queueNameEntities := make([]DomainEntities.QueueNameEntity, len(stats))
+		for i, queueStats := range stats {
+			queueNameEntities[i] = queueStats.GetName()
+		}
+
+		return queueNameEntities, nil
+	}
+
+	// Every name is checked before the first message is exported
+	queueNameEntities := make([]DomainEntities.QueueNameEntity, 0, len(queueNames))
+	seen := map[string]bool{}
+	for _, queueName := range queueNames {
+		queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[queueName] {
+			seen[queueName] = true
+			queueNameEntities = append(queueNameEntities, *queueNameEntity)
+		}
+	}
+
+	return queueNameEntities, nil
+}
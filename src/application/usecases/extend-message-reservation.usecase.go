@@ -0,0 +1,52 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+var ErrReservationNotFound = DomainEntities.NewNotFoundError("reservation_not_found", "message is not reserved by this consumer")
+
+type extendMessageReservationUsecase struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewExtendMessageReservationUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *extendMessageReservationUsecase {
+	return &extendMessageReservationUsecase{
+		queueRepository: queueRepository,
+	}
+}
+
+// Handle keeps the message reserved by reservedBy for reserveBySeconds from
+// now, so long running consumers do not lose it to another one. It fails
+// with ErrReservationNotFound when the message was acknowledged or reserved
+// by someone else meanwhile.
+func (usecase *extendMessageReservationUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, messageId string, reservedBy string, reserveBySeconds int) (time.Time, error) {
+	ctx, span := tracer.Start(ctx, "ExtendMessageReservationUsecase")
+	defer span.End()
+
+	if err := DomainEntities.CheckLimit("reserve_by_seconds", reserveBySeconds, DomainEntities.MaxReserveSeconds); err != nil {
+		return time.Time{}, err
+	}
+
+	if reservedBy == "" {
+		return time.Time{}, DomainEntities.NewFieldValidationError("reserved_by", "reserved_by cannot be empty")
+	}
+
+	reserveExpires := time.Now().Add(time.Duration(reserveBySeconds) * time.Second)
+
+	extended, err := usecase.queueRepository.ReleaseMessage(ctx, tenant.GetId(), messageId, reservedBy, reserveExpires)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !extended {
+		return time.Time{}, ErrReservationNotFound
+	}
+
+	return reserveExpires, nil
+}
@@ -1,6 +1,8 @@
 package ApplicationUsecases
 
 import (
+	"context"
+	"fmt"
 	DomainEntities "lean-queue/src/domain/entities"
 	DomainRepositories "lean-queue/src/domain/repositories"
 	"time"
@@ -18,22 +20,47 @@ func NewGetAndReserveNextMessagesUsecase(
 	}
 }
 
-func (usecase *getAndReserveNextMessagesUsecase) Handle(queueName string, limit int, reservedBy string, reserveBySeconds int, reservedInfo *string) ([]DomainEntities.QueueEntity, error) {
+func (usecase *getAndReserveNextMessagesUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string, limit int, filterExpressions []string, reservedBy string, reserveBySeconds int, reservedInfo *string) ([]DomainEntities.QueueEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetAndReserveNextMessagesUsecase")
+	defer span.End()
 
 	if *reservedInfo == "" {
 		reservedInfo = nil
 	}
 
-	queueNameEntity, err := DomainEntities.NewQueueName(queueName)
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := DomainEntities.CheckLimit("limit", limit, DomainEntities.MaxReserveLimit); err != nil {
+		return nil, err
+	}
+
+	if err := DomainEntities.CheckLimit("reserve_by_seconds", reserveBySeconds, DomainEntities.MaxReserveSeconds); err != nil {
+		return nil, err
+	}
+
+	if reservedBy == "" || len(reservedBy) > DomainEntities.MaxReservedBy {
+		return nil, DomainEntities.NewFieldValidationError("reserved_by", fmt.Sprintf("reserved_by must have between 1 and %d characters", DomainEntities.MaxReservedBy))
+	}
+
+	filters := make([]DomainEntities.AttributeFilterEntity, 0, len(filterExpressions))
+	for _, expression := range filterExpressions {
+		filter, err := DomainEntities.ParseAttributeFilter(expression)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, *filter)
+	}
+
 	expiresAt := time.Now().Add(time.Duration(reserveBySeconds) * time.Second)
 
 	messages, err := usecase.queueRepository.GetAndReserveMessages(
+		ctx,
 		*queueNameEntity,
 		limit,
+		filters,
 		time.Now(),
 		time.Now(),
 		reservedBy,
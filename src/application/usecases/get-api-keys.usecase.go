@@ -0,0 +1,28 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type getApiKeysUsecase struct {
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+}
+
+func NewGetApiKeysUsecase(
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *getApiKeysUsecase {
+	return &getApiKeysUsecase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+// Handle lists the API keys stored by the server. The keys in the config
+// file are not listed.
+func (usecase *getApiKeysUsecase) Handle(ctx context.Context) ([]DomainEntities.ApiKeyEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetApiKeysUsecase")
+	defer span.End()
+
+	return usecase.apiKeyRepository.ListApiKeys(ctx)
+}
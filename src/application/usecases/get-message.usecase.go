@@ -0,0 +1,43 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+var ErrMessageNotFound = DomainEntities.NewNotFoundError("message_not_found", "message not found")
+
+type getMessageUsecase struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewGetMessageUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *getMessageUsecase {
+	return &getMessageUsecase{
+		queueRepository: queueRepository,
+	}
+}
+
+// Handle returns a message of the tenant with its reservation history.
+func (usecase *getMessageUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, messageId string) (*DomainEntities.QueueEntity, []DomainEntities.MessageReservationEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetMessageUsecase")
+	defer span.End()
+
+	message, err := usecase.queueRepository.GetById(ctx, tenant.GetId(), messageId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if message == nil {
+		return nil, nil, ErrMessageNotFound
+	}
+
+	reservations, err := usecase.queueRepository.GetReservations(ctx, tenant.GetId(), messageId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return message, reservations, nil
+}
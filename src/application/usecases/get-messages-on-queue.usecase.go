@@ -1,8 +1,10 @@
 package ApplicationUsecases
 
 import (
+	"context"
 	DomainEntities "lean-queue/src/domain/entities"
 	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
 )
 
 type getMessagesOnQueueUsecase struct {
@@ -17,21 +19,57 @@ func NewGetMessagesOnQueueUsecase(
 	}
 }
 
-func (usecase *getMessagesOnQueueUsecase) Handle(queueName string, limit int) ([]DomainEntities.QueueEntity, error) {
+// Handle lists a page of up to limit messages of the queue matching filter,
+// starting after cursor when not empty. The returned cursor fetches the next
+// page and is empty on the last one.
+func (usecase *getMessagesOnQueueUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	queueName string,
+	filter DomainEntities.MessageListFilterEntity,
+	cursor string,
+	limit int,
+) ([]DomainEntities.QueueEntity, string, error) {
+	ctx, span := tracer.Start(ctx, "GetMessagesOnQueueUsecase")
+	defer span.End()
 
-	queueNameEntity, err := DomainEntities.NewQueueName(queueName)
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
+	if err := DomainEntities.CheckLimit("limit", limit, DomainEntities.MaxListLimit); err != nil {
+		return nil, "", err
+	}
+
+	var cursorEntity *DomainEntities.MessageCursorEntity
+	if cursor != "" {
+		cursorEntity, err = DomainEntities.ParseMessageCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// One more message than asked tells whether there is a next page
 	messages, err := usecase.queueRepository.GetMessages(
+		ctx,
 		*queueNameEntity,
-		limit,
+		filter,
+		cursorEntity,
+		limit+1,
+		time.Now(),
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return messages, nil
+	if len(messages) <= limit {
+		return messages, "", nil
+	}
+
+	messages = messages[:limit]
+	last := messages[limit-1]
+
+	return messages, DomainEntities.NewMessageCursor(last.GetPublishedAt(), last.GetId()).String(), nil
 }
@@ -0,0 +1,31 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type getPushSubscriptionsUsecase struct {
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface
+}
+
+func NewGetPushSubscriptionsUsecase(
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface,
+) *getPushSubscriptionsUsecase {
+	return &getPushSubscriptionsUsecase{
+		pushSubscriptionRepository: pushSubscriptionRepository,
+	}
+}
+
+func (usecase *getPushSubscriptionsUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string) ([]DomainEntities.PushSubscriptionEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetPushSubscriptionsUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.pushSubscriptionRepository.GetPushSubscriptions(ctx, *queueNameEntity)
+}
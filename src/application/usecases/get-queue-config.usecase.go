@@ -0,0 +1,31 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type getQueueConfigUsecase struct {
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+}
+
+func NewGetQueueConfigUsecase(
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+) *getQueueConfigUsecase {
+	return &getQueueConfigUsecase{
+		queueConfigRepository: queueConfigRepository,
+	}
+}
+
+func (usecase *getQueueConfigUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string) (*DomainEntities.QueueConfigEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetQueueConfigUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.queueConfigRepository.GetConfig(ctx, *queueNameEntity)
+}
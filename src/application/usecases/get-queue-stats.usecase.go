@@ -0,0 +1,32 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type getQueueStatsUsecase struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewGetQueueStatsUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *getQueueStatsUsecase {
+	return &getQueueStatsUsecase{
+		queueRepository: queueRepository,
+	}
+}
+
+func (usecase *getQueueStatsUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string) (*DomainEntities.QueueStatsEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetQueueStatsUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.queueRepository.GetQueueStats(ctx, *queueNameEntity, time.Now())
+}
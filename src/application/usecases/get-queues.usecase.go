@@ -0,0 +1,28 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type getQueuesUsecase struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewGetQueuesUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *getQueuesUsecase {
+	return &getQueuesUsecase{
+		queueRepository: queueRepository,
+	}
+}
+
+// Handle lists the stats of every queue of the tenant.
+func (usecase *getQueuesUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity) ([]DomainEntities.QueueStatsEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetQueuesUsecase")
+	defer span.End()
+
+	return usecase.queueRepository.ListQueueStats(ctx, tenant.GetId(), time.Now())
+}
@@ -0,0 +1,26 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type getSchedulesUsecase struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+}
+
+func NewGetSchedulesUsecase(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+) *getSchedulesUsecase {
+	return &getSchedulesUsecase{
+		scheduleRepository: scheduleRepository,
+	}
+}
+
+func (usecase *getSchedulesUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity) ([]DomainEntities.ScheduleEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetSchedulesUsecase")
+	defer span.End()
+
+	return usecase.scheduleRepository.ListSchedules(ctx, tenant.GetId())
+}
@@ -0,0 +1,40 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type getTopicsUsecase struct {
+	topicRepository DomainRepositories.TopicRepositoryInterface
+}
+
+func NewGetTopicsUsecase(
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *getTopicsUsecase {
+	return &getTopicsUsecase{
+		topicRepository: topicRepository,
+	}
+}
+
+// Handle lists the topics of the tenant, or only topic when not empty.
+func (usecase *getTopicsUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, topic string) ([]DomainEntities.TopicEntity, error) {
+	ctx, span := tracer.Start(ctx, "GetTopicsUsecase")
+	defer span.End()
+
+	if topic == "" {
+		return usecase.topicRepository.ListTopics(ctx, tenant.GetId())
+	}
+
+	topicEntity, err := usecase.topicRepository.GetTopic(ctx, tenant.GetId(), topic)
+	if err != nil {
+		return nil, err
+	}
+
+	if topicEntity == nil {
+		return nil, DomainEntities.ErrTopicNotFound
+	}
+
+	return []DomainEntities.TopicEntity{*topicEntity}, nil
+}
@@ -0,0 +1,129 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type importMessagesUsecase struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	batchSize             int
+}
+
+// NewImportMessagesUsecase saves batchSize messages per transaction, so an
+// import never holds more than that many messages in memory.
+func NewImportMessagesUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	batchSize int,
+) *importMessagesUsecase {
+	return &importMessagesUsecase{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		batchSize:             batchSize,
+	}
+}
+
+// Handle saves the messages returned by next until it returns io.EOF,
+// keeping their ids, publish times and reservations. Messages whose id is
+// already stored are skipped, so an interrupted import can be repeated.
+// Messages whose id belongs to another tenant fail the import with a line
+// detail, the position of the message counting from 1. It returns how many
+// messages were imported and skipped, counting on failure the batches saved
+// before it.
+func (usecase *importMessagesUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	next func() (*DomainEntities.QueueEntity, error),
+) (imported int64, skipped int64, err error) {
+	ctx, span := tracer.Start(ctx, "ImportMessagesUsecase")
+	defer span.End()
+
+	configs := map[string]*DomainEntities.QueueConfigEntity{}
+	batch := make([]DomainEntities.QueueEntity, 0, usecase.batchSize)
+
+	save := func() error {
+		saved, err := usecase.queueRepository.SaveManyNew(ctx, tenant, batch)
+		if err != nil {
+			return withMessageLine(err, batch, imported+skipped)
+		}
+
+		imported += saved
+		skipped += int64(len(batch)) - saved
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for {
+		message, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, skipped, err
+		}
+
+		if message.GetName().GetTenant() != tenant.GetId() {
+			return imported, skipped, DomainEntities.NewFieldValidationError("queue_name", "queue name belongs to another tenant")
+		}
+
+		config, ok := configs[message.GetName().GetValue()]
+		if !ok {
+			config, err = usecase.queueConfigRepository.GetConfig(ctx, message.GetName())
+			if err != nil {
+				return imported, skipped, err
+			}
+			configs[message.GetName().GetValue()] = config
+		}
+
+		err = config.CheckMessageSize(message.GetMessage())
+		if err != nil {
+			return imported, skipped, err
+		}
+
+		batch = append(batch, *message)
+
+		if len(batch) >= usecase.batchSize {
+			if err := save(); err != nil {
+				return imported, skipped, err
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := save(); err != nil {
+			return imported, skipped, err
+		}
+	}
+
+	return imported, skipped, nil
+}
+
+// withMessageLine adds the line of the message of batch named by the
+// message_id detail of err, before being how many messages came earlier.
+func withMessageLine(err error, batch []DomainEntities.QueueEntity, before int64) error {
+	var domainError *DomainEntities.DomainError
+	if !errors.As(err, &domainError) {
+		return err
+	}
+
+	for index, message := range batch {
+		if message.GetId() != domainError.GetDetails()["message_id"] {
+			continue
+		}
+
+		details := map[string]interface{}{}
+		for key, value := range domainError.GetDetails() {
+			details[key] = value
+		}
+		details["line"] = before + int64(index) + 1
+		return domainError.WithDetails(details)
+	}
+
+	return err
+}
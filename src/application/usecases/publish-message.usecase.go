@@ -1,45 +1,84 @@
 package ApplicationUsecases
 
 import (
+	"context"
 	DomainEntities "lean-queue/src/domain/entities"
 	DomainRepositories "lean-queue/src/domain/repositories"
 	"time"
 )
 
 type publishMessageUsecase struct {
-	queueRepository DomainRepositories.QueueRepositoryInterface
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
 }
 
 func NewPublishMessageUsecase(
 	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
 ) *publishMessageUsecase {
 	return &publishMessageUsecase{
-		queueRepository: queueRepository,
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
 	}
 }
 
-func (usecase *publishMessageUsecase) Handle(queueName string, message string) error {
+// Handle publishes the message and returns its id. When deduplicationId was
+// already published on the queue within its window, nothing is inserted and
+// the original message id is returned with deduplicated set.
+func (usecase *publishMessageUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string, message string, attributes map[string]string, groupId *string, expiresAt *time.Time, deduplicationId string) (messageId string, deduplicated bool, err error) {
+	ctx, span := tracer.Start(ctx, "PublishMessageUsecase")
+	defer span.End()
 
-	queueNameEntity, err := DomainEntities.NewQueueName(queueName)
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
 	if err != nil {
-		return err
+		return "", false, err
 	}
 
 	messageEntity, err := DomainEntities.NewQueueMessage(message)
 	if err != nil {
-		return err
+		return "", false, err
 	}
 
-	queueEntity, err := DomainEntities.NewQueue(nil, *queueNameEntity, *messageEntity, time.Now(), nil, nil, nil, nil, time.Now())
+	attributesEntity, err := DomainEntities.NewMessageAttributes(attributes)
+	if err != nil {
+		return "", false, err
+	}
+
+	config, err := usecase.queueConfigRepository.GetConfig(ctx, *queueNameEntity)
+	if err != nil {
+		return "", false, err
+	}
+
+	err = config.CheckMessageSize(*messageEntity)
+	if err != nil {
+		return "", false, err
+	}
+
+	now := time.Now()
+	if expiresAt == nil && config.GetDefaultTtl() > 0 {
+		defaultExpiresAt := now.Add(config.GetDefaultTtl())
+		expiresAt = &defaultExpiresAt
+	}
+
+	queueEntity, err := DomainEntities.NewQueue(nil, *queueNameEntity, *messageEntity, *attributesEntity, groupId, expiresAt, now, nil, nil, nil, nil, now, traceparentFromContext(ctx))
 
 	if err != nil {
-		return err
+		return "", false, err
+	}
+
+	if deduplicationId == "" {
+		err = usecase.queueRepository.Save(ctx, tenant, *queueEntity)
+		if err != nil {
+			return "", false, err
+		}
+
+		return queueEntity.GetId(), false, nil
 	}
 
-	err = usecase.queueRepository.Save(*queueEntity)
+	deduplicationIdEntity, err := DomainEntities.NewDeduplicationId(deduplicationId)
 	if err != nil {
-		return err
+		return "", false, err
 	}
 
-	return nil
+	return usecase.queueRepository.SaveDeduplicated(ctx, tenant, *queueEntity, *deduplicationIdEntity, now, now.Add(config.GetDeduplicationWindow()))
 }
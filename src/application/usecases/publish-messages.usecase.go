@@ -0,0 +1,117 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	"errors"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+// PublishBatchMessage is one message of a batch publish.
+type PublishBatchMessage struct {
+	Message    string
+	Attributes map[string]string
+	GroupId    *string
+	ExpiresAt  *time.Time
+}
+
+type publishMessagesUsecase struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+}
+
+func NewPublishMessagesUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+) *publishMessagesUsecase {
+	return &publishMessagesUsecase{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+	}
+}
+
+// Handle publishes every message to queueName, all of them or none, and
+// returns their ids in order. An invalid message fails the batch with its
+// index in the error details.
+func (usecase *publishMessagesUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string, messages []PublishBatchMessage) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "PublishMessagesUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = DomainEntities.CheckLimit("messages", len(messages), DomainEntities.MaxPublishBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := usecase.queueConfigRepository.GetConfig(ctx, *queueNameEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	traceparent := traceparentFromContext(ctx)
+	queueEntities := make([]DomainEntities.QueueEntity, 0, len(messages))
+	messageIds := make([]string, 0, len(messages))
+
+	for index, message := range messages {
+		queueEntity, err := newBatchMessage(*queueNameEntity, *config, message, now, traceparent)
+		if err != nil {
+			var domainError *DomainEntities.DomainError
+			if errors.As(err, &domainError) {
+				details := map[string]interface{}{}
+				for key, value := range domainError.GetDetails() {
+					details[key] = value
+				}
+				details["index"] = index
+				err = domainError.WithDetails(details)
+			}
+			return nil, err
+		}
+
+		queueEntities = append(queueEntities, *queueEntity)
+		messageIds = append(messageIds, queueEntity.GetId())
+	}
+
+	err = usecase.queueRepository.SaveMany(ctx, tenant, queueEntities)
+	if err != nil {
+		return nil, err
+	}
+
+	return messageIds, nil
+}
+
+func newBatchMessage(
+	queueName DomainEntities.QueueNameEntity,
+	config DomainEntities.QueueConfigEntity,
+	message PublishBatchMessage,
+	now time.Time,
+	traceparent *string,
+) (*DomainEntities.QueueEntity, error) {
+	messageEntity, err := DomainEntities.NewQueueMessage(message.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	attributesEntity, err := DomainEntities.NewMessageAttributes(message.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.CheckMessageSize(*messageEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := message.ExpiresAt
+	if expiresAt == nil && config.GetDefaultTtl() > 0 {
+		defaultExpiresAt := now.Add(config.GetDefaultTtl())
+		expiresAt = &defaultExpiresAt
+	}
+
+	return DomainEntities.NewQueue(nil, queueName, *messageEntity, *attributesEntity, message.GroupId, expiresAt, now, nil, nil, nil, nil, now, traceparent)
+}
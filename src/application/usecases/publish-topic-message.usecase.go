@@ -0,0 +1,104 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type publishTopicMessageUsecase struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	topicRepository       DomainRepositories.TopicRepositoryInterface
+}
+
+func NewPublishTopicMessageUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *publishTopicMessageUsecase {
+	return &publishTopicMessageUsecase{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		topicRepository:       topicRepository,
+	}
+}
+
+// Handle inserts, atomically, one copy of the message in the queue of every
+// subscription whose filters match its attributes, returning the copies.
+func (usecase *publishTopicMessageUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	topic string,
+	message string,
+	attributes map[string]string,
+	groupId *string,
+	expiresAt *time.Time,
+) ([]DomainEntities.QueueEntity, error) {
+	ctx, span := tracer.Start(ctx, "PublishTopicMessageUsecase")
+	defer span.End()
+
+	messageEntity, err := DomainEntities.NewQueueMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	attributesEntity, err := DomainEntities.NewMessageAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	topicEntity, err := usecase.topicRepository.GetTopic(ctx, tenant.GetId(), topic)
+	if err != nil {
+		return nil, err
+	}
+
+	if topicEntity == nil {
+		return nil, DomainEntities.ErrTopicNotFound
+	}
+
+	now := time.Now()
+	traceparent := traceparentFromContext(ctx)
+	messages := []DomainEntities.QueueEntity{}
+
+	for _, subscription := range topicEntity.GetSubscriptions() {
+		if !subscription.Matches(*attributesEntity) {
+			continue
+		}
+
+		config, err := usecase.queueConfigRepository.GetConfig(ctx, subscription.GetQueueName())
+		if err != nil {
+			return nil, err
+		}
+
+		err = config.CheckMessageSize(*messageEntity)
+		if err != nil {
+			return nil, err
+		}
+
+		copyExpiresAt := expiresAt
+		if copyExpiresAt == nil && config.GetDefaultTtl() > 0 {
+			defaultExpiresAt := now.Add(config.GetDefaultTtl())
+			copyExpiresAt = &defaultExpiresAt
+		}
+
+		queueEntity, err := DomainEntities.NewQueue(nil, subscription.GetQueueName(), *messageEntity, *attributesEntity, groupId, copyExpiresAt, now, nil, nil, nil, nil, now, traceparent)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, *queueEntity)
+	}
+
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	err = usecase.queueRepository.SaveMany(ctx, tenant, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,128 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PurgeConfirmationTtl is how long a purge confirmation token is accepted.
+const PurgeConfirmationTtl = 5 * time.Minute
+
+var ErrInvalidConfirmationToken = DomainEntities.NewDomainError(DomainEntities.ErrorKindForbidden, "invalid_confirmation_token", "invalid or expired confirmation token")
+
+type purgeQueueUsecase struct {
+	queueRepository    DomainRepositories.QueueRepositoryInterface
+	confirmationSecret []byte
+	batchSize          int
+}
+
+// NewPurgeQueueUsecase signs confirmation tokens with confirmationSecret,
+// which must be shared by every instance, and deletes batchSize messages per
+// statement so no lock is held for long.
+func NewPurgeQueueUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	confirmationSecret []byte,
+	batchSize int,
+) *purgeQueueUsecase {
+	return &purgeQueueUsecase{
+		queueRepository:    queueRepository,
+		confirmationSecret: confirmationSecret,
+		batchSize:          batchSize,
+	}
+}
+
+// ConfirmationToken returns the token Handle requires to purge queueName
+// with the same options, valid for PurgeConfirmationTtl.
+func (usecase *purgeQueueUsecase) ConfirmationToken(
+	tenant DomainEntities.TenantEntity,
+	queueName string,
+	onlyVisible bool,
+	olderThanSeconds int,
+	now time.Time,
+) (string, time.Time) {
+	expiresAt := now.Add(PurgeConfirmationTtl)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return expires + "." + usecase.sign(tenant, queueName, onlyVisible, olderThanSeconds, expires), expiresAt
+}
+
+// Handle deletes the messages of queueName, only the visible ones when
+// onlyVisible and only those published more than olderThanSeconds ago when
+// not 0, returning how many were deleted.
+func (usecase *purgeQueueUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	queueName string,
+	onlyVisible bool,
+	olderThanSeconds int,
+	confirmationToken string,
+	now time.Time,
+) (int64, error) {
+	ctx, span := tracer.Start(ctx, "PurgeQueueUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return 0, err
+	}
+
+	if olderThanSeconds < 0 {
+		return 0, DomainEntities.NewValidationError("olderThanSeconds cannot be negative")
+	}
+
+	expires, signature, found := strings.Cut(confirmationToken, ".")
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if !found || err != nil || now.Unix() > expiresUnix {
+		return 0, ErrInvalidConfirmationToken
+	}
+
+	expected := usecase.sign(tenant, queueName, onlyVisible, olderThanSeconds, expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return 0, ErrInvalidConfirmationToken
+	}
+
+	var visibleAt *time.Time
+	if onlyVisible {
+		visibleAt = &now
+	}
+
+	var publishedBefore *time.Time
+	if olderThanSeconds > 0 {
+		publishedBeforeTime := now.Add(-time.Duration(olderThanSeconds) * time.Second)
+		publishedBefore = &publishedBeforeTime
+	}
+
+	var deleted int64
+	for {
+		removed, err := usecase.queueRepository.PurgeMessages(ctx, *queueNameEntity, visibleAt, publishedBefore, usecase.batchSize)
+		deleted += removed
+		if err != nil {
+			return deleted, err
+		}
+
+		if removed < int64(usecase.batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+func (usecase *purgeQueueUsecase) sign(tenant DomainEntities.TenantEntity, queueName string, onlyVisible bool, olderThanSeconds int, expires string) string {
+	mac := hmac.New(sha256.New, usecase.confirmationSecret)
+	mac.Write([]byte(strings.Join([]string{
+		"purge",
+		tenant.GetId(),
+		queueName,
+		strconv.FormatBool(onlyVisible),
+		strconv.Itoa(olderThanSeconds),
+		expires,
+	}, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,111 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type redriveQueueUsecase struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	batchSize             int
+}
+
+// NewRedriveQueueUsecase moves batchSize messages per transaction.
+func NewRedriveQueueUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	batchSize int,
+) *redriveQueueUsecase {
+	return &redriveQueueUsecase{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		batchSize:             batchSize,
+	}
+}
+
+// Handle moves up to limit visible messages of the dead letter queue
+// queueName back into targetQueue, every one of them when limit is 0. An
+// empty targetQueue means the only queue using queueName as dead letter
+// queue. It returns the target queue and how many messages were moved.
+func (usecase *redriveQueueUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	queueName string,
+	targetQueue string,
+	limit int,
+	now time.Time,
+) (*DomainEntities.QueueNameEntity, int64, error) {
+	ctx, span := tracer.Start(ctx, "RedriveQueueUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit < 0 {
+		return nil, 0, DomainEntities.NewFieldValidationError("limit", "limit cannot be negative")
+	}
+
+	targetEntity, err := usecase.target(ctx, *queueNameEntity, targetQueue)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var moved int64
+	for limit == 0 || moved < int64(limit) {
+		batchSize := usecase.batchSize
+		if limit > 0 && int64(limit)-moved < int64(batchSize) {
+			batchSize = limit - int(moved)
+		}
+
+		ids, err := usecase.queueRepository.RedriveMessages(ctx, *queueNameEntity, *targetEntity, now, batchSize)
+		moved += int64(len(ids))
+		if err != nil {
+			return targetEntity, moved, err
+		}
+
+		if len(ids) < batchSize {
+			break
+		}
+	}
+
+	return targetEntity, moved, nil
+}
+
+func (usecase *redriveQueueUsecase) target(ctx context.Context, queueName DomainEntities.QueueNameEntity, targetQueue string) (*DomainEntities.QueueNameEntity, error) {
+	if targetQueue != "" {
+		targetEntity, err := DomainEntities.NewTenantQueueName(queueName.GetTenant(), targetQueue)
+		if err != nil {
+			return nil, err
+		}
+
+		if targetEntity.GetValue() == queueName.GetValue() {
+			return nil, DomainEntities.NewFieldValidationError("to_queue", "to_queue cannot be the queue itself")
+		}
+
+		return targetEntity, nil
+	}
+
+	configs, err := usecase.queueConfigRepository.ListConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []DomainEntities.QueueNameEntity
+	for _, config := range configs {
+		deadLetterQueue := config.GetDeadLetterQueue()
+		if config.GetName().GetTenant() == queueName.GetTenant() && deadLetterQueue != nil && deadLetterQueue.GetValue() == queueName.GetValue() {
+			sources = append(sources, config.GetName())
+		}
+	}
+
+	if len(sources) != 1 {
+		return nil, DomainEntities.NewFieldValidationError("to_queue", "to_queue is required unless exactly one queue uses this queue as dead letter queue")
+	}
+
+	return &sources[0], nil
+}
@@ -0,0 +1,37 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type removeApiKeyUsecase struct {
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+}
+
+func NewRemoveApiKeyUsecase(
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *removeApiKeyUsecase {
+	return &removeApiKeyUsecase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+// Handle revokes the stored key named name. Servers may keep accepting it
+// for as long as they cache authenticated keys.
+func (usecase *removeApiKeyUsecase) Handle(ctx context.Context, name string) error {
+	ctx, span := tracer.Start(ctx, "RemoveApiKeyUsecase")
+	defer span.End()
+
+	removed, err := usecase.apiKeyRepository.RemoveApiKey(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return DomainEntities.ErrApiKeyNotFound
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package ApplicationUsecases
 
 import (
+	"context"
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
 	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
 )
 
 type removeMessageUsecase struct {
@@ -16,9 +20,23 @@ func NewRemoveMessageUsecase(
 	}
 }
 
-func (usecase *removeMessageUsecase) Handle(messageId string) error {
+// Handle removes a processed message. When its queue archives, the message
+// is kept in the archive as completed by completedBy, or by the consumer
+// last reserving it when empty.
+func (usecase *removeMessageUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, messageId string, completedBy string) error {
+	ctx, span := tracer.Start(ctx, "RemoveMessageUsecase")
+	defer span.End()
 
-	err := usecase.queueRepository.RemoveById(messageId)
+	if len(completedBy) > DomainEntities.MaxReservedBy {
+		return DomainEntities.NewFieldValidationError("completed_by", fmt.Sprintf("completed_by cannot be longer than %d characters", DomainEntities.MaxReservedBy))
+	}
+
+	var completedByPtr *string
+	if completedBy != "" {
+		completedByPtr = &completedBy
+	}
+
+	err := usecase.queueRepository.CompleteById(ctx, tenant.GetId(), messageId, completedByPtr, time.Now())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,42 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+var ErrPushSubscriptionNotFound = DomainEntities.NewNotFoundError("push_subscription_not_found", "push subscription not found")
+
+type removePushSubscriptionUsecase struct {
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface
+}
+
+func NewRemovePushSubscriptionUsecase(
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface,
+) *removePushSubscriptionUsecase {
+	return &removePushSubscriptionUsecase{
+		pushSubscriptionRepository: pushSubscriptionRepository,
+	}
+}
+
+func (usecase *removePushSubscriptionUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string, subscriptionId string) error {
+	ctx, span := tracer.Start(ctx, "RemovePushSubscriptionUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return err
+	}
+
+	removed, err := usecase.pushSubscriptionRepository.RemovePushSubscription(ctx, *queueNameEntity, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return ErrPushSubscriptionNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type removeScheduleUsecase struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+}
+
+func NewRemoveScheduleUsecase(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+) *removeScheduleUsecase {
+	return &removeScheduleUsecase{
+		scheduleRepository: scheduleRepository,
+	}
+}
+
+func (usecase *removeScheduleUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, name string) error {
+	ctx, span := tracer.Start(ctx, "RemoveScheduleUsecase")
+	defer span.End()
+
+	removed, err := usecase.scheduleRepository.RemoveSchedule(ctx, tenant.GetId(), name)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return DomainEntities.ErrScheduleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+// pushReservationMargin keeps a message reserved a bit longer than the
+// delivery may take, so it is settled before anyone else can reserve it.
+const pushReservationMargin = 10 * time.Second
+
+type reservePushMessagesUsecase struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewReservePushMessagesUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *reservePushMessagesUsecase {
+	return &reservePushMessagesUsecase{
+		queueRepository: queueRepository,
+	}
+}
+
+// Handle reserves up to limit messages of the subscription queue for
+// delivery by the push worker.
+func (usecase *reservePushMessagesUsecase) Handle(
+	ctx context.Context,
+	subscription DomainEntities.PushSubscriptionEntity,
+	limit int,
+	now time.Time,
+) ([]DomainEntities.QueueEntity, error) {
+	ctx, span := tracer.Start(ctx, "ReservePushMessagesUsecase")
+	defer span.End()
+
+	reserveExpires := now.Add(subscription.GetTimeout() + pushReservationMargin)
+
+	return usecase.queueRepository.GetAndReserveMessages(
+		ctx,
+		subscription.GetQueueName(),
+		limit,
+		nil,
+		now,
+		now,
+		subscription.GetReservedBy(),
+		nil,
+		&reserveExpires,
+	)
+}
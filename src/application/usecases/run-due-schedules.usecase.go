@@ -0,0 +1,111 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+type runDueSchedulesUsecase struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	scheduleRepository    DomainRepositories.ScheduleRepositoryInterface
+	tenants               map[string]DomainEntities.TenantEntity
+}
+
+// NewRunDueSchedulesUsecase publishes on behalf of the tenants, so their
+// quotas apply to schedules too. Tenants missing from tenants have no quota.
+func NewRunDueSchedulesUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+	tenants map[string]DomainEntities.TenantEntity,
+) *runDueSchedulesUsecase {
+	return &runDueSchedulesUsecase{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		scheduleRepository:    scheduleRepository,
+		tenants:               tenants,
+	}
+}
+
+// Handle publishes one message for every schedule due at now, in batches of
+// batchSize, and moves each schedule to its next run after now. Runs missed
+// while no scheduler was running follow the schedule missed run policy.
+func (usecase *runDueSchedulesUsecase) Handle(ctx context.Context, now time.Time, batchSize int) error {
+	ctx, span := tracer.Start(ctx, "RunDueSchedulesUsecase")
+	defer span.End()
+
+	for {
+		schedules, err := usecase.scheduleRepository.GetDueSchedules(ctx, now, batchSize)
+		if err != nil {
+			return err
+		}
+
+		// Failed runs stay due, so another batch would fetch them again
+		failed := false
+		for _, schedule := range schedules {
+			if err := usecase.run(ctx, schedule, now); err != nil {
+				slog.Error("failed to run schedule", "schedule", schedule.GetName(), "queue_name", schedule.GetQueueName().GetValue(), "error", err)
+				failed = true
+			}
+		}
+
+		if failed || len(schedules) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (usecase *runDueSchedulesUsecase) run(ctx context.Context, schedule DomainEntities.ScheduleEntity, now time.Time) error {
+	scheduledAt := schedule.GetNextRunAt()
+	nextRunAt := schedule.NextRunAfter(now)
+
+	missed := now.Sub(scheduledAt) > DomainEntities.ScheduleMissedRunGrace
+	if missed && schedule.GetMissedRunPolicy() == DomainEntities.MissedRunPolicySkip {
+		slog.Warn("skipped missed schedule run", "schedule", schedule.GetName(), "scheduled_at", scheduledAt)
+		return usecase.scheduleRepository.SetScheduleRun(ctx, schedule.GetId(), nil, nextRunAt)
+	}
+
+	payload, err := schedule.RenderPayload(scheduledAt)
+	if err != nil {
+		// A template failing at run time would fail again, so the run is skipped
+		setErr := usecase.scheduleRepository.SetScheduleRun(ctx, schedule.GetId(), nil, nextRunAt)
+		if setErr != nil {
+			return setErr
+		}
+		return err
+	}
+
+	tenant, ok := usecase.tenants[schedule.GetQueueName().GetTenant()]
+	if !ok {
+		tenantEntity, err := DomainEntities.NewTenant(schedule.GetQueueName().GetTenant(), 0, 0)
+		if err != nil {
+			return err
+		}
+		tenant = *tenantEntity
+	}
+
+	// The deduplication id keeps a run published once even if the schedule
+	// could not be moved to its next run afterwards
+	deduplicationId := "schedule:" + schedule.GetId() + ":" + strconv.FormatInt(scheduledAt.Unix(), 10)
+
+	_, _, err = NewPublishMessageUsecase(usecase.queueRepository, usecase.queueConfigRepository).Handle(
+		ctx,
+		tenant,
+		schedule.GetQueueName().GetValue(),
+		payload,
+		schedule.GetAttributes().GetValues(),
+		nil,
+		nil,
+		deduplicationId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return usecase.scheduleRepository.SetScheduleRun(ctx, schedule.GetId(), &scheduledAt, nextRunAt)
+}
@@ -0,0 +1,92 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type saveQueueConfigUsecase struct {
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+}
+
+func NewSaveQueueConfigUsecase(
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+) *saveQueueConfigUsecase {
+	return &saveQueueConfigUsecase{
+		queueConfigRepository: queueConfigRepository,
+	}
+}
+
+func (usecase *saveQueueConfigUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	queueName string,
+	retentionSeconds int,
+	maxLength int,
+	maxReceives int,
+	deadLetterQueue string,
+	eventsQueue string,
+	deduplicationWindowSeconds int,
+	defaultTtlSeconds int,
+	expirationPolicy string,
+	maxMessageBytes int,
+	archive bool,
+	archiveRetentionSeconds int,
+) (*DomainEntities.QueueConfigEntity, error) {
+	ctx, span := tracer.Start(ctx, "SaveQueueConfigUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadLetterQueueEntity *DomainEntities.QueueNameEntity
+	if deadLetterQueue != "" {
+		deadLetterQueueEntity, err = DomainEntities.NewTenantQueueName(tenant.GetId(), deadLetterQueue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var eventsQueueEntity *DomainEntities.QueueNameEntity
+	if eventsQueue != "" {
+		eventsQueueEntity, err = DomainEntities.NewTenantQueueName(tenant.GetId(), eventsQueue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Pausing has its own endpoints, so saving the config keeps it as is
+	existing, err := usecase.queueConfigRepository.GetConfig(ctx, *queueNameEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := DomainEntities.NewQueueConfig(
+		*queueNameEntity,
+		retentionSeconds,
+		maxLength,
+		maxReceives,
+		deadLetterQueueEntity,
+		eventsQueueEntity,
+		deduplicationWindowSeconds,
+		defaultTtlSeconds,
+		DomainEntities.ExpirationPolicy(expirationPolicy),
+		maxMessageBytes,
+		archive,
+		archiveRetentionSeconds,
+		existing.IsPaused(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = usecase.queueConfigRepository.SaveConfig(ctx, *config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
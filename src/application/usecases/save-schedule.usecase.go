@@ -0,0 +1,83 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type saveScheduleUsecase struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+}
+
+func NewSaveScheduleUsecase(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+) *saveScheduleUsecase {
+	return &saveScheduleUsecase{
+		scheduleRepository: scheduleRepository,
+	}
+}
+
+// Handle creates the schedule called name or replaces its definition, in
+// which case the next run is computed again from now.
+func (usecase *saveScheduleUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	name string,
+	cronExpression string,
+	timeZone string,
+	queueName string,
+	payload string,
+	attributes map[string]string,
+	missedRunPolicy string,
+	paused bool,
+) (*DomainEntities.ScheduleEntity, error) {
+	ctx, span := tracer.Start(ctx, "SaveScheduleUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	attributesEntity, err := DomainEntities.NewMessageAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	existing, err := usecase.scheduleRepository.GetSchedule(ctx, tenant.GetId(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	var id *string
+	var lastRunAt *time.Time
+	if existing != nil {
+		existingId := existing.GetId()
+		id = &existingId
+		lastRunAt = existing.GetLastRunAt()
+	}
+
+	// Built once to validate the definition and compute the next run from now
+	schedule, err := DomainEntities.NewSchedule(id, name, cronExpression, timeZone, *queueNameEntity, payload, *attributesEntity, DomainEntities.MissedRunPolicy(missedRunPolicy), paused, time.Time{}, lastRunAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		schedule, err = DomainEntities.NewSchedule(id, name, cronExpression, timeZone, *queueNameEntity, payload, *attributesEntity, DomainEntities.MissedRunPolicy(missedRunPolicy), paused, schedule.GetNextRunAt(), lastRunAt, existing.GetCreatedAt())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = usecase.scheduleRepository.SaveSchedule(ctx, *schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
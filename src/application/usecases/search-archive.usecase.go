@@ -0,0 +1,68 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type searchArchiveUsecase struct {
+	queueArchiveRepository DomainRepositories.QueueArchiveRepositoryInterface
+}
+
+func NewSearchArchiveUsecase(
+	queueArchiveRepository DomainRepositories.QueueArchiveRepositoryInterface,
+) *searchArchiveUsecase {
+	return &searchArchiveUsecase{
+		queueArchiveRepository: queueArchiveRepository,
+	}
+}
+
+// Handle lists a page of up to limit archived messages of the queue matching
+// filter, most recently completed first, starting after cursor when not
+// empty. The returned cursor fetches the next page and is empty on the last
+// one.
+func (usecase *searchArchiveUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	queueName string,
+	filter DomainEntities.ArchiveFilterEntity,
+	cursor string,
+	limit int,
+) ([]DomainEntities.ArchivedMessageEntity, string, error) {
+	ctx, span := tracer.Start(ctx, "SearchArchiveUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := DomainEntities.CheckLimit("limit", limit, DomainEntities.MaxListLimit); err != nil {
+		return nil, "", err
+	}
+
+	var cursorEntity *DomainEntities.MessageCursorEntity
+	if cursor != "" {
+		cursorEntity, err = DomainEntities.ParseMessageCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// One more message than asked tells whether there is a next page
+	archived, err := usecase.queueArchiveRepository.SearchArchive(ctx, *queueNameEntity, filter, cursorEntity, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(archived) <= limit {
+		return archived, "", nil
+	}
+
+	archived = archived[:limit]
+	last := archived[limit-1]
+	lastMessage := last.GetMessage()
+
+	return archived, DomainEntities.NewMessageCursor(last.GetCompletedAt(), lastMessage.GetId()).String(), nil
+}
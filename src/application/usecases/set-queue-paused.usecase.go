@@ -0,0 +1,38 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+type setQueuePausedUsecase struct {
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+}
+
+func NewSetQueuePausedUsecase(
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+) *setQueuePausedUsecase {
+	return &setQueuePausedUsecase{
+		queueConfigRepository: queueConfigRepository,
+	}
+}
+
+// Handle pauses or resumes consumption of a queue. While paused, publishes
+// are still accepted but reservations return no messages.
+func (usecase *setQueuePausedUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, queueName string, paused bool) (*DomainEntities.QueueConfigEntity, error) {
+	ctx, span := tracer.Start(ctx, "SetQueuePausedUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = usecase.queueConfigRepository.SetPaused(ctx, *queueNameEntity, paused)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.queueConfigRepository.GetConfig(ctx, *queueNameEntity)
+}
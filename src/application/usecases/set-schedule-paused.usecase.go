@@ -0,0 +1,71 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type setSchedulePausedUsecase struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+}
+
+func NewSetSchedulePausedUsecase(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+) *setSchedulePausedUsecase {
+	return &setSchedulePausedUsecase{
+		scheduleRepository: scheduleRepository,
+	}
+}
+
+// Handle pauses or resumes a schedule. Runs that fall while paused are not
+// published: resuming computes the next run from now.
+func (usecase *setSchedulePausedUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, name string, paused bool) (*DomainEntities.ScheduleEntity, error) {
+	ctx, span := tracer.Start(ctx, "SetSchedulePausedUsecase")
+	defer span.End()
+
+	schedule, err := usecase.scheduleRepository.GetSchedule(ctx, tenant.GetId(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule == nil {
+		return nil, DomainEntities.ErrScheduleNotFound
+	}
+
+	if schedule.IsPaused() == paused {
+		return schedule, nil
+	}
+
+	nextRunAt := schedule.GetNextRunAt()
+	if !paused {
+		nextRunAt = schedule.NextRunAfter(time.Now())
+	}
+
+	id := schedule.GetId()
+	schedule, err = DomainEntities.NewSchedule(
+		&id,
+		schedule.GetName(),
+		schedule.GetCronExpression(),
+		schedule.GetTimeZone(),
+		schedule.GetQueueName(),
+		schedule.GetPayload(),
+		schedule.GetAttributes(),
+		schedule.GetMissedRunPolicy(),
+		paused,
+		nextRunAt,
+		schedule.GetLastRunAt(),
+		schedule.GetCreatedAt(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = usecase.scheduleRepository.SaveSchedule(ctx, *schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
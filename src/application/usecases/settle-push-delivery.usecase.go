@@ -0,0 +1,105 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type settlePushDeliveryUsecase struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	retryBase             time.Duration
+	retryMax              time.Duration
+}
+
+func NewSettlePushDeliveryUsecase(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	retryBase time.Duration,
+	retryMax time.Duration,
+) *settlePushDeliveryUsecase {
+	return &settlePushDeliveryUsecase{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		retryBase:             retryBase,
+		retryMax:              retryMax,
+	}
+}
+
+// Handle removes a delivered message, archiving it when its queue archives.
+// A failed one is retried with exponential backoff, unless it reached the
+// max receives of its queue, in which case it is moved to the dead letter
+// queue right away.
+func (usecase *settlePushDeliveryUsecase) Handle(
+	ctx context.Context,
+	subscription DomainEntities.PushSubscriptionEntity,
+	message DomainEntities.QueueEntity,
+	delivered bool,
+	now time.Time,
+) error {
+	ctx, span := tracer.Start(ctx, "SettlePushDeliveryUsecase")
+	defer span.End()
+
+	tenant := subscription.GetQueueName().GetTenant()
+
+	if delivered {
+		reservedBy := subscription.GetReservedBy()
+		return usecase.queueRepository.CompleteById(ctx, tenant, message.GetId(), &reservedBy, now)
+	}
+
+	config, err := usecase.queueConfigRepository.GetConfig(ctx, subscription.GetQueueName())
+	if err != nil {
+		return err
+	}
+
+	attempts := 1
+	if message.GetReservedCount() != nil {
+		attempts = *message.GetReservedCount()
+	}
+
+	exhausted := config.GetMaxReceives() > 0 && attempts >= config.GetMaxReceives()
+
+	visibleAt := now.Add(usecase.backoff(attempts))
+	if exhausted {
+		visibleAt = now
+	}
+
+	released, err := usecase.queueRepository.ReleaseMessage(ctx, tenant, message.GetId(), subscription.GetReservedBy(), visibleAt)
+	if err != nil || !released || !exhausted {
+		return err
+	}
+
+	messageIds, err := usecase.queueRepository.MoveExhaustedMessages(
+		ctx,
+		config.GetName(),
+		config.GetMaxReceives(),
+		*config.GetDeadLetterQueue(),
+		now.Add(time.Millisecond),
+		DomainEntities.MaxPushMaxConcurrency,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, messageId := range messageIds {
+		emitQueueEvent(ctx, usecase.queueRepository, *config, "message.dead_lettered", "max_receives", messageId)
+	}
+
+	return nil
+}
+
+// backoff doubles retryBase for every attempt after the first, up to retryMax.
+func (usecase *settlePushDeliveryUsecase) backoff(attempts int) time.Duration {
+	delay := usecase.retryBase
+	for i := 1; i < attempts && delay < usecase.retryMax; i++ {
+		delay *= 2
+	}
+
+	if delay > usecase.retryMax {
+		return usecase.retryMax
+	}
+
+	return delay
+}
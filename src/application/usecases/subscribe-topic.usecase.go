@@ -0,0 +1,77 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	"time"
+)
+
+type subscribeTopicUsecase struct {
+	topicRepository DomainRepositories.TopicRepositoryInterface
+}
+
+func NewSubscribeTopicUsecase(
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *subscribeTopicUsecase {
+	return &subscribeTopicUsecase{
+		topicRepository: topicRepository,
+	}
+}
+
+// Handle subscribes queueName to topic. Subscribing a queue again replaces
+// the filters of its existing subscription.
+func (usecase *subscribeTopicUsecase) Handle(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	topic string,
+	queueName string,
+	filterExpressions []string,
+) (*DomainEntities.TopicSubscriptionEntity, error) {
+	ctx, span := tracer.Start(ctx, "SubscribeTopicUsecase")
+	defer span.End()
+
+	queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]DomainEntities.AttributeFilterEntity, 0, len(filterExpressions))
+	for _, expression := range filterExpressions {
+		filter, err := DomainEntities.ParseAttributeFilter(expression)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, *filter)
+	}
+
+	var id *string
+	createdAt := time.Now()
+
+	topicEntity, err := usecase.topicRepository.GetTopic(ctx, tenant.GetId(), topic)
+	if err != nil {
+		return nil, err
+	}
+
+	if topicEntity != nil {
+		for _, existing := range topicEntity.GetSubscriptions() {
+			if existing.GetQueueName().GetValue() == queueName {
+				existingId := existing.GetId()
+				id = &existingId
+				createdAt = existing.GetCreatedAt()
+			}
+		}
+	}
+
+	subscription, err := DomainEntities.NewTopicSubscription(id, topic, *queueNameEntity, filters, createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = usecase.topicRepository.SaveSubscription(ctx, tenant.GetId(), *subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
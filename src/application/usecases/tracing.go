@@ -0,0 +1,24 @@
+package ApplicationUsecases
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var tracer = otel.Tracer("lean-queue/usecases")
+
+// traceparentFromContext returns the W3C traceparent of the span in ctx, or
+// nil when the request is not traced.
+func traceparentFromContext(ctx context.Context) *string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	traceparent, ok := carrier["traceparent"]
+	if !ok {
+		return nil
+	}
+
+	return &traceparent
+}
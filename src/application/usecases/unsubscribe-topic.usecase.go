@@ -0,0 +1,37 @@
+package ApplicationUsecases
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+)
+
+var ErrSubscriptionNotFound = DomainEntities.NewNotFoundError("subscription_not_found", "subscription not found")
+
+type unsubscribeTopicUsecase struct {
+	topicRepository DomainRepositories.TopicRepositoryInterface
+}
+
+func NewUnsubscribeTopicUsecase(
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *unsubscribeTopicUsecase {
+	return &unsubscribeTopicUsecase{
+		topicRepository: topicRepository,
+	}
+}
+
+func (usecase *unsubscribeTopicUsecase) Handle(ctx context.Context, tenant DomainEntities.TenantEntity, topic string, subscriptionId string) error {
+	ctx, span := tracer.Start(ctx, "UnsubscribeTopicUsecase")
+	defer span.End()
+
+	removed, err := usecase.topicRepository.RemoveSubscription(ctx, tenant.GetId(), topic, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return ErrSubscriptionNotFound
+	}
+
+	return nil
+}
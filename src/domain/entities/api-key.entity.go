@@ -0,0 +1,94 @@
+package DomainEntities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+var ErrApiKeyNotFound = NewNotFoundError("api_key_not_found", "api key not found")
+
+type ApiKeyEntity struct {
+	name      string
+	tenant    string
+	keyHash   string
+	admin     bool
+	createdAt time.Time
+}
+
+// NewApiKey describes a key stored by the server, identified by name and
+// operating on the namespace of tenant. Only the hash of the key is kept.
+func NewApiKey(
+	name string,
+	tenant string,
+	keyHash string,
+	admin bool,
+	createdAt time.Time,
+) (*ApiKeyEntity, error) {
+
+	if name == "" {
+		return nil, NewValidationError("api key name cannot be empty")
+	}
+
+	if len(name) > 128 {
+		return nil, NewValidationError("api key name cannot be longer than 128 characters")
+	}
+
+	if len(tenant) > 64 {
+		return nil, NewValidationError("tenant id cannot be longer than 64 characters")
+	}
+
+	if len(keyHash) != sha256.Size*2 {
+		return nil, NewValidationError("api key hash must be a hex encoded sha256")
+	}
+
+	if createdAt.IsZero() {
+		return nil, NewValidationError("createdAt cannot be zero")
+	}
+
+	return &ApiKeyEntity{
+		name:      name,
+		tenant:    tenant,
+		keyHash:   keyHash,
+		admin:     admin,
+		createdAt: createdAt,
+	}, nil
+}
+
+// GenerateApiKey returns a new random key, hex encoded.
+func GenerateApiKey() (string, error) {
+	keyBytes := make([]byte, 32)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(keyBytes), nil
+}
+
+// HashApiKey returns the hash stored for key.
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func (k ApiKeyEntity) GetName() string {
+	return k.name
+}
+
+func (k ApiKeyEntity) GetTenant() string {
+	return k.tenant
+}
+
+func (k ApiKeyEntity) GetKeyHash() string {
+	return k.keyHash
+}
+
+// IsAdmin reports whether the key may run admin operations.
+func (k ApiKeyEntity) IsAdmin() bool {
+	return k.admin
+}
+
+func (k ApiKeyEntity) GetCreatedAt() time.Time {
+	return k.createdAt
+}
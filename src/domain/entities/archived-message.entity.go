@@ -0,0 +1,115 @@
+package DomainEntities
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxArchiveContainsLength bounds the text searched in archived messages.
+const MaxArchiveContainsLength = 255
+
+// ArchivedMessageEntity is a message removed from an archiving queue, as it
+// was when completed, with its reservation history.
+type ArchivedMessageEntity struct {
+	message      QueueEntity
+	reservations []MessageReservationEntity
+	completedAt  time.Time
+	completedBy  *string
+}
+
+func NewArchivedMessage(
+	message QueueEntity,
+	reservations []MessageReservationEntity,
+	completedAt time.Time,
+	completedBy *string,
+) *ArchivedMessageEntity {
+	return &ArchivedMessageEntity{
+		message:      message,
+		reservations: reservations,
+		completedAt:  completedAt,
+		completedBy:  completedBy,
+	}
+}
+
+func (am *ArchivedMessageEntity) GetMessage() QueueEntity {
+	return am.message
+}
+
+// GetReservations returns the reservations of the message, oldest first.
+func (am *ArchivedMessageEntity) GetReservations() []MessageReservationEntity {
+	return am.reservations
+}
+
+func (am *ArchivedMessageEntity) GetCompletedAt() time.Time {
+	return am.completedAt
+}
+
+// GetCompletedBy returns who removed the message: the consumer naming
+// itself on removal, else the last one to reserve it.
+func (am *ArchivedMessageEntity) GetCompletedBy() *string {
+	return am.completedBy
+}
+
+type ArchiveFilterEntity struct {
+	messageId        *string
+	completedBy      *string
+	completedAfter   *time.Time
+	completedBefore  *time.Time
+	attributeFilters []AttributeFilterEntity
+	contains         string
+}
+
+// NewArchiveFilter selects the archived messages of a queue with messageId,
+// completed by completedBy in the [completedAfter, completedBefore) range,
+// matching every attribute filter and whose body contains the given text.
+// Unset conditions select every message.
+func NewArchiveFilter(
+	messageId *string,
+	completedBy *string,
+	completedAfter *time.Time,
+	completedBefore *time.Time,
+	attributeFilters []AttributeFilterEntity,
+	contains string,
+) (*ArchiveFilterEntity, error) {
+
+	if completedAfter != nil && completedBefore != nil && !completedAfter.Before(*completedBefore) {
+		return nil, NewValidationError("completedAfter must be before completedBefore")
+	}
+
+	if len(contains) > MaxArchiveContainsLength {
+		return nil, NewFieldValidationError("contains", fmt.Sprintf("contains cannot be longer than %d bytes", MaxArchiveContainsLength))
+	}
+
+	return &ArchiveFilterEntity{
+		messageId:        messageId,
+		completedBy:      completedBy,
+		completedAfter:   completedAfter,
+		completedBefore:  completedBefore,
+		attributeFilters: attributeFilters,
+		contains:         contains,
+	}, nil
+}
+
+func (af *ArchiveFilterEntity) GetMessageId() *string {
+	return af.messageId
+}
+
+func (af *ArchiveFilterEntity) GetCompletedBy() *string {
+	return af.completedBy
+}
+
+func (af *ArchiveFilterEntity) GetCompletedAfter() *time.Time {
+	return af.completedAfter
+}
+
+func (af *ArchiveFilterEntity) GetCompletedBefore() *time.Time {
+	return af.completedBefore
+}
+
+func (af *ArchiveFilterEntity) GetAttributeFilters() []AttributeFilterEntity {
+	return af.attributeFilters
+}
+
+func (af *ArchiveFilterEntity) GetContains() string {
+	return af.contains
+}
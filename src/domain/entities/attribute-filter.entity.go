@@ -0,0 +1,103 @@
+package DomainEntities
+
+import (
+	"fmt"
+	"strings"
+)
+
+type AttributeFilterOperator string
+
+const (
+	AttributeEquals    AttributeFilterOperator = "="
+	AttributeNotEquals AttributeFilterOperator = "!="
+	AttributeExists    AttributeFilterOperator = "exists"
+	AttributeNotExists AttributeFilterOperator = "not_exists"
+)
+
+// AttributeFilterEntity is a condition on one message attribute. Messages
+// without the attribute match != and not_exists.
+type AttributeFilterEntity struct {
+	name     string
+	operator AttributeFilterOperator
+	value    string
+}
+
+func NewAttributeFilter(name string, operator AttributeFilterOperator, value string) (*AttributeFilterEntity, error) {
+
+	if !messageAttributeNameRegexp.MatchString(name) || len(name) > MaxMessageAttributeName {
+		return nil, NewValidationError(fmt.Sprintf("invalid attribute name %q in filter", name))
+	}
+
+	switch operator {
+	case AttributeEquals, AttributeNotEquals:
+		if len(value) > MaxMessageAttributeValue {
+			return nil, NewValidationError(fmt.Sprintf("attribute %q filter value cannot be longer than %d bytes", name, MaxMessageAttributeValue))
+		}
+	case AttributeExists, AttributeNotExists:
+		value = ""
+	default:
+		return nil, NewValidationError(fmt.Sprintf("invalid attribute filter operator %q", operator))
+	}
+
+	return &AttributeFilterEntity{name: name, operator: operator, value: value}, nil
+}
+
+// ParseAttributeFilter reads the expressions "name=value", "name!=value",
+// "name" (exists) and "!name" (does not exist). The operator is the first
+// "=" or "!=", so values may contain either: "url!=a!=b" is url != "a!=b".
+func ParseAttributeFilter(expression string) (*AttributeFilterEntity, error) {
+	// Names cannot contain '=', so the first one is the operator even when
+	// the value holds another "=" or "!="
+	if index := strings.Index(expression, "="); index >= 0 {
+		if index > 0 && expression[index-1] == '!' {
+			return NewAttributeFilter(expression[:index-1], AttributeNotEquals, expression[index+1:])
+		}
+		return NewAttributeFilter(expression[:index], AttributeEquals, expression[index+1:])
+	}
+
+	if strings.HasPrefix(expression, "!") {
+		return NewAttributeFilter(expression[1:], AttributeNotExists, "")
+	}
+
+	return NewAttributeFilter(expression, AttributeExists, "")
+}
+
+func (af AttributeFilterEntity) GetName() string {
+	return af.name
+}
+
+func (af AttributeFilterEntity) GetOperator() AttributeFilterOperator {
+	return af.operator
+}
+
+func (af AttributeFilterEntity) GetValue() string {
+	return af.value
+}
+
+func (af AttributeFilterEntity) Matches(attributes MessageAttributesEntity) bool {
+	value, ok := attributes.Get(af.name)
+
+	switch af.operator {
+	case AttributeEquals:
+		return ok && value == af.value
+	case AttributeNotEquals:
+		return !ok || value != af.value
+	case AttributeExists:
+		return ok
+	case AttributeNotExists:
+		return !ok
+	}
+
+	return false
+}
+
+func (af AttributeFilterEntity) String() string {
+	switch af.operator {
+	case AttributeExists:
+		return af.name
+	case AttributeNotExists:
+		return "!" + af.name
+	}
+
+	return af.name + string(af.operator) + af.value
+}
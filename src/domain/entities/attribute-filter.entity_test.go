@@ -0,0 +1,44 @@
+package DomainEntities
+
+import "testing"
+
+func TestParseAttributeFilter(t *testing.T) {
+	tests := []struct {
+		expression string
+		name       string
+		operator   AttributeFilterOperator
+		value      string
+	}{
+		{"env=prod", "env", AttributeEquals, "prod"},
+		{"env!=prod", "env", AttributeNotEquals, "prod"},
+		{"env", "env", AttributeExists, ""},
+		{"!env", "env", AttributeNotExists, ""},
+		{"env=", "env", AttributeEquals, ""},
+		{"query=a=b", "query", AttributeEquals, "a=b"},
+		{"query=a!=b", "query", AttributeEquals, "a!=b"},
+		{"query!=a!=b", "query", AttributeNotEquals, "a!=b"},
+		{"query!=a=b", "query", AttributeNotEquals, "a=b"},
+	}
+
+	for _, test := range tests {
+		filter, err := ParseAttributeFilter(test.expression)
+		if err != nil {
+			t.Errorf("ParseAttributeFilter(%q) failed: %v", test.expression, err)
+			continue
+		}
+
+		if filter.GetName() != test.name || filter.GetOperator() != test.operator || filter.GetValue() != test.value {
+			t.Errorf("ParseAttributeFilter(%q) = %q %q %q, want %q %q %q",
+				test.expression, filter.GetName(), filter.GetOperator(), filter.GetValue(),
+				test.name, test.operator, test.value)
+		}
+	}
+}
+
+func TestParseAttributeFilterInvalidName(t *testing.T) {
+	for _, expression := range []string{"=prod", "!=prod", "!"} {
+		if _, err := ParseAttributeFilter(expression); err == nil {
+			t.Errorf("ParseAttributeFilter(%q) succeeded, want an error", expression)
+		}
+	}
+}
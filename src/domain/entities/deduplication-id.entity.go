@@ -0,0 +1,22 @@
+package DomainEntities
+
+type DeduplicationIdEntity struct {
+	value string
+}
+
+func NewDeduplicationId(value string) (*DeduplicationIdEntity, error) {
+
+	if value == "" {
+		return nil, NewFieldValidationError("deduplication_id", "deduplication id cannot be empty")
+	}
+
+	if len(value) > 128 {
+		return nil, NewFieldValidationError("deduplication_id", "deduplication id cannot be longer than 128 characters")
+	}
+
+	return &DeduplicationIdEntity{value: value}, nil
+}
+
+func (di DeduplicationIdEntity) GetValue() string {
+	return di.value
+}
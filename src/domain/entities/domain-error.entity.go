@@ -0,0 +1,75 @@
+package DomainEntities
+
+type ErrorKind string
+
+const (
+	ErrorKindValidation      ErrorKind = "validation"
+	ErrorKindUnauthorized    ErrorKind = "unauthorized"
+	ErrorKindForbidden       ErrorKind = "forbidden"
+	ErrorKindNotFound        ErrorKind = "not_found"
+	ErrorKindConflict        ErrorKind = "conflict"
+	ErrorKindTooManyRequests ErrorKind = "too_many_requests"
+	ErrorKindUnavailable     ErrorKind = "unavailable"
+)
+
+// DomainError is an error the client can act upon. Its kind decides the
+// response status and its code, stable across versions, identifies it
+// among the errors of the same kind.
+type DomainError struct {
+	kind    ErrorKind
+	code    string
+	message string
+	details map[string]interface{}
+}
+
+func NewDomainError(kind ErrorKind, code string, message string) *DomainError {
+	return &DomainError{
+		kind:    kind,
+		code:    code,
+		message: message,
+	}
+}
+
+// NewValidationError reports invalid input, all with the validation_failed code.
+func NewValidationError(message string) *DomainError {
+	return NewDomainError(ErrorKindValidation, "validation_failed", message)
+}
+
+// NewFieldValidationError reports an invalid value of the input field.
+func NewFieldValidationError(field string, message string) *DomainError {
+	return NewValidationError(message).WithDetails(map[string]interface{}{"field": field})
+}
+
+func NewNotFoundError(code string, message string) *DomainError {
+	return NewDomainError(ErrorKindNotFound, code, message)
+}
+
+func (de *DomainError) Error() string {
+	return de.message
+}
+
+func (de *DomainError) GetKind() ErrorKind {
+	return de.kind
+}
+
+func (de *DomainError) GetCode() string {
+	return de.code
+}
+
+func (de *DomainError) GetMessage() string {
+	return de.message
+}
+
+func (de *DomainError) GetDetails() map[string]interface{} {
+	return de.details
+}
+
+// WithDetails returns a copy of the error carrying details about it.
+func (de *DomainError) WithDetails(details map[string]interface{}) *DomainError {
+	return &DomainError{
+		kind:    de.kind,
+		code:    de.code,
+		message: de.message,
+		details: details,
+	}
+}
@@ -0,0 +1,22 @@
+package DomainEntities
+
+import (
+	"fmt"
+)
+
+const (
+	MaxReserveLimit   = 100
+	MaxListLimit      = 1000
+	MaxReserveSeconds = 12 * 60 * 60
+	MaxReservedBy     = 255
+	MaxPublishBatch   = 100
+)
+
+// CheckLimit fails, reporting field, unless value is between 1 and max.
+func CheckLimit(field string, value int, max int) error {
+	if value < 1 || value > max {
+		return NewFieldValidationError(field, fmt.Sprintf("%s must be between 1 and %d", field, max))
+	}
+
+	return nil
+}
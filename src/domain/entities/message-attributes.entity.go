@@ -0,0 +1,87 @@
+package DomainEntities
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	MaxMessageAttributes        = 32
+	MaxMessageAttributeName     = 128
+	MaxMessageAttributeValue    = 1024
+	MaxMessageAttributesBytes   = 16 * 1024
+	messageAttributeNamePattern = `^[A-Za-z0-9_.\-]+$`
+)
+
+var messageAttributeNameRegexp = regexp.MustCompile(messageAttributeNamePattern)
+
+// MessageAttributesEntity holds the key/value metadata of a message, such as
+// content type or correlation id, readable without parsing the body.
+type MessageAttributesEntity struct {
+	values map[string]string
+}
+
+func NewMessageAttributes(values map[string]string) (*MessageAttributesEntity, error) {
+
+	if len(values) > MaxMessageAttributes {
+		return nil, NewFieldValidationError("attributes", fmt.Sprintf("attributes cannot have more than %d entries", MaxMessageAttributes))
+	}
+
+	totalBytes := 0
+	copied := make(map[string]string, len(values))
+
+	for name, value := range values {
+		if name == "" {
+			return nil, NewFieldValidationError("attributes", "attribute name cannot be empty")
+		}
+
+		if len(name) > MaxMessageAttributeName {
+			return nil, NewFieldValidationError("attributes", fmt.Sprintf("attribute name %q cannot be longer than %d bytes", name, MaxMessageAttributeName))
+		}
+
+		if !messageAttributeNameRegexp.MatchString(name) {
+			return nil, NewFieldValidationError("attributes", fmt.Sprintf("attribute name %q can only contain letters, digits, '_', '.' and '-'", name))
+		}
+
+		if len(value) > MaxMessageAttributeValue {
+			return nil, NewFieldValidationError("attributes", fmt.Sprintf("attribute %q value cannot be longer than %d bytes", name, MaxMessageAttributeValue))
+		}
+
+		totalBytes += len(name) + len(value)
+		copied[name] = value
+	}
+
+	if totalBytes > MaxMessageAttributesBytes {
+		return nil, NewFieldValidationError("attributes", fmt.Sprintf("attributes cannot exceed %d bytes", MaxMessageAttributesBytes))
+	}
+
+	return &MessageAttributesEntity{values: copied}, nil
+}
+
+// RestoreMessageAttributes rebuilds stored attributes without validating them again.
+func RestoreMessageAttributes(values map[string]string) MessageAttributesEntity {
+	copied := make(map[string]string, len(values))
+	for name, value := range values {
+		copied[name] = value
+	}
+
+	return MessageAttributesEntity{values: copied}
+}
+
+func (ma MessageAttributesEntity) Get(name string) (string, bool) {
+	value, ok := ma.values[name]
+	return value, ok
+}
+
+func (ma MessageAttributesEntity) Len() int {
+	return len(ma.values)
+}
+
+// GetValues returns a copy of the attributes, never nil.
+func (ma MessageAttributesEntity) GetValues() map[string]string {
+	values := make(map[string]string, len(ma.values))
+	for name, value := range ma.values {
+		values[name] = value
+	}
+	return values
+}
@@ -0,0 +1,125 @@
+package DomainEntities
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+type MessageListFilterEntity struct {
+	states           []MessageState
+	reservedBy       *string
+	publishedAfter   *time.Time
+	publishedBefore  *time.Time
+	minReservedCount int
+	descending       bool
+}
+
+// NewMessageListFilter selects the listed messages of a queue: those in any
+// of states (all when empty), reserved by reservedBy, published in the
+// [publishedAfter, publishedBefore) range and reserved at least
+// minReservedCount times, newest first when descending.
+func NewMessageListFilter(
+	states []MessageState,
+	reservedBy *string,
+	publishedAfter *time.Time,
+	publishedBefore *time.Time,
+	minReservedCount int,
+	descending bool,
+) (*MessageListFilterEntity, error) {
+
+	for _, state := range states {
+		switch state {
+		case MessageStateVisible, MessageStateInFlight, MessageStateDelayed, MessageStateExpired:
+		default:
+			return nil, NewValidationError("state must be visible, in_flight, delayed or expired")
+		}
+	}
+
+	if publishedAfter != nil && publishedBefore != nil && !publishedAfter.Before(*publishedBefore) {
+		return nil, NewValidationError("publishedAfter must be before publishedBefore")
+	}
+
+	if minReservedCount < 0 {
+		return nil, NewValidationError("minReservedCount cannot be negative")
+	}
+
+	return &MessageListFilterEntity{
+		states:           states,
+		reservedBy:       reservedBy,
+		publishedAfter:   publishedAfter,
+		publishedBefore:  publishedBefore,
+		minReservedCount: minReservedCount,
+		descending:       descending,
+	}, nil
+}
+
+func (mf *MessageListFilterEntity) GetStates() []MessageState {
+	return mf.states
+}
+
+func (mf *MessageListFilterEntity) GetReservedBy() *string {
+	return mf.reservedBy
+}
+
+func (mf *MessageListFilterEntity) GetPublishedAfter() *time.Time {
+	return mf.publishedAfter
+}
+
+func (mf *MessageListFilterEntity) GetPublishedBefore() *time.Time {
+	return mf.publishedBefore
+}
+
+func (mf *MessageListFilterEntity) GetMinReservedCount() int {
+	return mf.minReservedCount
+}
+
+func (mf *MessageListFilterEntity) IsDescending() bool {
+	return mf.descending
+}
+
+// MessageCursorEntity points right after a listed message, in list order.
+type MessageCursorEntity struct {
+	publishedAt time.Time
+	id          string
+}
+
+func NewMessageCursor(publishedAt time.Time, id string) *MessageCursorEntity {
+	return &MessageCursorEntity{
+		publishedAt: publishedAt,
+		id:          id,
+	}
+}
+
+// ParseMessageCursor decodes a cursor returned by MessageCursorEntity.String.
+func ParseMessageCursor(value string) (*MessageCursorEntity, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, NewValidationError("invalid cursor")
+	}
+
+	publishedAtStr, id, found := strings.Cut(string(decoded), "|")
+	if !found || id == "" {
+		return nil, NewValidationError("invalid cursor")
+	}
+
+	publishedAt, err := time.Parse(time.RFC3339Nano, publishedAtStr)
+	if err != nil {
+		return nil, NewValidationError("invalid cursor")
+	}
+
+	return NewMessageCursor(publishedAt, id), nil
+}
+
+func (mc *MessageCursorEntity) GetPublishedAt() time.Time {
+	return mc.publishedAt
+}
+
+func (mc *MessageCursorEntity) GetId() string {
+	return mc.id
+}
+
+// String encodes the cursor as an opaque URL safe token.
+func (mc *MessageCursorEntity) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(mc.publishedAt.UTC().Format(time.RFC3339Nano) + "|" + mc.id))
+}
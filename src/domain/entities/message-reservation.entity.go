@@ -0,0 +1,43 @@
+package DomainEntities
+
+import (
+	"time"
+)
+
+// MessageReservationEntity is one past or current reservation of a message.
+type MessageReservationEntity struct {
+	reservedAt     time.Time
+	reservedBy     *string
+	reservedInfo   *string
+	reserveExpires time.Time
+}
+
+func NewMessageReservation(
+	reservedAt time.Time,
+	reservedBy *string,
+	reservedInfo *string,
+	reserveExpires time.Time,
+) *MessageReservationEntity {
+	return &MessageReservationEntity{
+		reservedAt:     reservedAt,
+		reservedBy:     reservedBy,
+		reservedInfo:   reservedInfo,
+		reserveExpires: reserveExpires,
+	}
+}
+
+func (mr *MessageReservationEntity) GetReservedAt() time.Time {
+	return mr.reservedAt
+}
+
+func (mr *MessageReservationEntity) GetReservedBy() *string {
+	return mr.reservedBy
+}
+
+func (mr *MessageReservationEntity) GetReservedInfo() *string {
+	return mr.reservedInfo
+}
+
+func (mr *MessageReservationEntity) GetReserveExpires() time.Time {
+	return mr.reserveExpires
+}
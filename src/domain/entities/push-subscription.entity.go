@@ -0,0 +1,149 @@
+package DomainEntities
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPushMaxConcurrency = 1
+	DefaultPushTimeoutSeconds = 30
+	MaxPushMaxConcurrency     = 100
+	MaxPushTimeoutSeconds     = 300
+)
+
+var ErrPushHostNotAllowed = NewDomainError(ErrorKindForbidden, "push_host_not_allowed", "push url host is not allowed")
+
+type PushSubscriptionEntity struct {
+	id             string
+	queueName      QueueNameEntity
+	url            string
+	secret         string
+	maxConcurrency int
+	timeoutSeconds int
+	createdAt      time.Time
+}
+
+// NewPushSubscription has the server reserve the messages of queueName and
+// POST them, signed with secret, to url, keeping at most maxConcurrency
+// deliveries in flight. A delivery not answered with 2xx in timeoutSeconds
+// is retried later.
+func NewPushSubscription(
+	id *string,
+	queueName QueueNameEntity,
+	endpoint string,
+	secret string,
+	maxConcurrency int,
+	timeoutSeconds int,
+	createdAt time.Time,
+) (*PushSubscriptionEntity, error) {
+
+	if id == nil {
+		newUuid := uuid.New().String()
+		id = &newUuid
+	}
+
+	if queueName.value == "" {
+		return nil, NewValidationError("queue name cannot be empty")
+	}
+
+	parsedUrl, err := url.Parse(endpoint)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return nil, NewValidationError("url must be an absolute http or https url")
+	}
+
+	if secret == "" {
+		return nil, NewValidationError("secret cannot be empty")
+	}
+
+	if maxConcurrency < 1 || maxConcurrency > MaxPushMaxConcurrency {
+		return nil, NewValidationError("max concurrency must be between 1 and 100")
+	}
+
+	if timeoutSeconds < 1 || timeoutSeconds > MaxPushTimeoutSeconds {
+		return nil, NewValidationError("timeout must be between 1 and 300 seconds")
+	}
+
+	if createdAt.IsZero() {
+		return nil, NewValidationError("createdAt cannot be zero")
+	}
+
+	return &PushSubscriptionEntity{
+		id:             *id,
+		queueName:      queueName,
+		url:            endpoint,
+		secret:         secret,
+		maxConcurrency: maxConcurrency,
+		timeoutSeconds: timeoutSeconds,
+		createdAt:      createdAt,
+	}, nil
+}
+
+func (ps *PushSubscriptionEntity) GetId() string {
+	return ps.id
+}
+
+func (ps *PushSubscriptionEntity) GetQueueName() QueueNameEntity {
+	return ps.queueName
+}
+
+func (ps *PushSubscriptionEntity) GetUrl() string {
+	return ps.url
+}
+
+func (ps *PushSubscriptionEntity) GetSecret() string {
+	return ps.secret
+}
+
+func (ps *PushSubscriptionEntity) GetMaxConcurrency() int {
+	return ps.maxConcurrency
+}
+
+func (ps *PushSubscriptionEntity) GetTimeoutSeconds() int {
+	return ps.timeoutSeconds
+}
+
+func (ps *PushSubscriptionEntity) GetTimeout() time.Duration {
+	return time.Duration(ps.timeoutSeconds) * time.Second
+}
+
+func (ps *PushSubscriptionEntity) GetCreatedAt() time.Time {
+	return ps.createdAt
+}
+
+// GetReservedBy identifies the deliveries of this subscription in the
+// reserved_by of the messages it reserves.
+func (ps *PushSubscriptionEntity) GetReservedBy() string {
+	return "push:" + ps.id
+}
+
+// IsHostAllowed reports whether the url host is one of allowedHosts, entries
+// starting with "*." matching any subdomain of the rest. Every host is
+// allowed when allowedHosts is empty.
+func (ps *PushSubscriptionEntity) IsHostAllowed(allowedHosts []string) bool {
+	if len(allowedHosts) == 0 {
+		return true
+	}
+
+	parsedUrl, err := url.Parse(ps.url)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsedUrl.Hostname(), "."))
+
+	for _, allowedHost := range allowedHosts {
+		allowedHost = strings.ToLower(allowedHost)
+		if domain, ok := strings.CutPrefix(allowedHost, "*."); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == allowedHost {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,40 @@
+package DomainEntities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPushSubscriptionIsHostAllowed(t *testing.T) {
+	allowedHosts := []string{"hooks.example.com", "*.internal.example.com"}
+
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://hooks.example.com/push", true},
+		{"https://HOOKS.example.com:8443/push", true},
+		{"https://hooks.example.com./push", true},
+		{"https://a.internal.example.com/push", true},
+		{"https://a.b.internal.example.com/push", true},
+		{"https://internal.example.com/push", false},
+		{"https://evilinternal.example.com/push", false},
+		{"https://hooks.example.com.evil.com/push", false},
+		{"http://127.0.0.1/push", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+	}
+
+	for _, test := range tests {
+		subscription, err := NewPushSubscription(nil, RestoreTenantQueueName("", "orders"), test.url, "secret", 1, 30, time.Now())
+		if err != nil {
+			t.Fatalf("NewPushSubscription(%q) failed: %v", test.url, err)
+		}
+
+		if allowed := subscription.IsHostAllowed(allowedHosts); allowed != test.allowed {
+			t.Errorf("IsHostAllowed(%q) = %v, want %v", test.url, allowed, test.allowed)
+		}
+		if !subscription.IsHostAllowed(nil) {
+			t.Errorf("IsHostAllowed(%q) without allowed hosts = false, want true", test.url)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package DomainEntities
+
+import (
+	"fmt"
+	"time"
+)
+
+const DefaultDeduplicationWindow = 5 * time.Minute
+
+type ExpirationPolicy string
+
+const (
+	ExpirationPolicyDelete     ExpirationPolicy = "delete"
+	ExpirationPolicyDeadLetter ExpirationPolicy = "dead_letter"
+)
+
+type QueueConfigEntity struct {
+	name                       QueueNameEntity
+	retentionSeconds           int
+	maxLength                  int
+	maxReceives                int
+	deadLetterQueue            *QueueNameEntity
+	eventsQueue                *QueueNameEntity
+	deduplicationWindowSeconds int
+	defaultTtlSeconds          int
+	expirationPolicy           ExpirationPolicy
+	maxMessageBytes            int
+	archive                    bool
+	archiveRetentionSeconds    int
+	paused                     bool
+}
+
+// NewQueueConfig holds the policies of a queue; zero values disable them.
+func NewQueueConfig(
+	name QueueNameEntity,
+	retentionSeconds int,
+	maxLength int,
+	maxReceives int,
+	deadLetterQueue *QueueNameEntity,
+	eventsQueue *QueueNameEntity,
+	deduplicationWindowSeconds int,
+	defaultTtlSeconds int,
+	expirationPolicy ExpirationPolicy,
+	maxMessageBytes int,
+	archive bool,
+	archiveRetentionSeconds int,
+	paused bool,
+) (*QueueConfigEntity, error) {
+
+	if name.value == "" {
+		return nil, NewValidationError("queue name cannot be empty")
+	}
+
+	if retentionSeconds < 0 {
+		return nil, NewValidationError("retentionSeconds cannot be negative")
+	}
+
+	if maxLength < 0 {
+		return nil, NewValidationError("maxLength cannot be negative")
+	}
+
+	if maxReceives < 0 {
+		return nil, NewValidationError("maxReceives cannot be negative")
+	}
+
+	if maxReceives > 0 && deadLetterQueue == nil {
+		return nil, NewValidationError("deadLetterQueue is required when maxReceives is set")
+	}
+
+	if deadLetterQueue != nil && deadLetterQueue.value == name.value {
+		return nil, NewValidationError("deadLetterQueue cannot be the queue itself")
+	}
+
+	if eventsQueue != nil && eventsQueue.value == name.value {
+		return nil, NewValidationError("eventsQueue cannot be the queue itself")
+	}
+
+	if deduplicationWindowSeconds < 0 {
+		return nil, NewValidationError("deduplicationWindowSeconds cannot be negative")
+	}
+
+	if defaultTtlSeconds < 0 {
+		return nil, NewValidationError("defaultTtlSeconds cannot be negative")
+	}
+
+	if maxMessageBytes < 0 || maxMessageBytes > MaxQueueMessageBytes {
+		return nil, NewFieldValidationError("max_message_bytes", fmt.Sprintf("maxMessageBytes must be between 0 and %d", MaxQueueMessageBytes))
+	}
+
+	if archiveRetentionSeconds < 0 {
+		return nil, NewFieldValidationError("archive_retention_seconds", "archiveRetentionSeconds cannot be negative")
+	}
+
+	switch expirationPolicy {
+	case "":
+		expirationPolicy = ExpirationPolicyDelete
+	case ExpirationPolicyDelete:
+	case ExpirationPolicyDeadLetter:
+		if deadLetterQueue == nil {
+			return nil, NewValidationError("deadLetterQueue is required by the dead_letter expirationPolicy")
+		}
+	default:
+		return nil, NewValidationError("expirationPolicy must be delete or dead_letter")
+	}
+
+	return &QueueConfigEntity{
+		name:                       name,
+		retentionSeconds:           retentionSeconds,
+		maxLength:                  maxLength,
+		maxReceives:                maxReceives,
+		deadLetterQueue:            deadLetterQueue,
+		eventsQueue:                eventsQueue,
+		deduplicationWindowSeconds: deduplicationWindowSeconds,
+		defaultTtlSeconds:          defaultTtlSeconds,
+		expirationPolicy:           expirationPolicy,
+		maxMessageBytes:            maxMessageBytes,
+		archive:                    archive,
+		archiveRetentionSeconds:    archiveRetentionSeconds,
+		paused:                     paused,
+	}, nil
+}
+
+func (qc *QueueConfigEntity) GetName() QueueNameEntity {
+	return qc.name
+}
+
+func (qc *QueueConfigEntity) GetRetentionSeconds() int {
+	return qc.retentionSeconds
+}
+
+func (qc *QueueConfigEntity) GetRetention() time.Duration {
+	return time.Duration(qc.retentionSeconds) * time.Second
+}
+
+func (qc *QueueConfigEntity) GetMaxLength() int {
+	return qc.maxLength
+}
+
+func (qc *QueueConfigEntity) GetMaxReceives() int {
+	return qc.maxReceives
+}
+
+func (qc *QueueConfigEntity) GetDeadLetterQueue() *QueueNameEntity {
+	return qc.deadLetterQueue
+}
+
+func (qc *QueueConfigEntity) GetEventsQueue() *QueueNameEntity {
+	return qc.eventsQueue
+}
+
+func (qc *QueueConfigEntity) GetDeduplicationWindowSeconds() int {
+	return qc.deduplicationWindowSeconds
+}
+
+// GetDeduplicationWindow returns how long a deduplication id is remembered,
+// DefaultDeduplicationWindow when not configured.
+func (qc *QueueConfigEntity) GetDeduplicationWindow() time.Duration {
+	if qc.deduplicationWindowSeconds == 0 {
+		return DefaultDeduplicationWindow
+	}
+
+	return time.Duration(qc.deduplicationWindowSeconds) * time.Second
+}
+
+func (qc *QueueConfigEntity) GetDefaultTtlSeconds() int {
+	return qc.defaultTtlSeconds
+}
+
+// GetDefaultTtl returns the time to live of messages published without one,
+// 0 meaning they never expire.
+func (qc *QueueConfigEntity) GetDefaultTtl() time.Duration {
+	return time.Duration(qc.defaultTtlSeconds) * time.Second
+}
+
+// GetExpirationPolicy tells whether expired messages are deleted or moved to
+// the dead letter queue.
+func (qc *QueueConfigEntity) GetExpirationPolicy() ExpirationPolicy {
+	return qc.expirationPolicy
+}
+
+func (qc *QueueConfigEntity) GetMaxMessageBytes() int {
+	return qc.maxMessageBytes
+}
+
+// CheckMessageSize fails when message is larger than the queue accepts,
+// MaxQueueMessageBytes when the queue sets no limit of its own.
+func (qc *QueueConfigEntity) CheckMessageSize(message QueueMessageEntity) error {
+	if qc.maxMessageBytes > 0 && message.GetSize() > qc.maxMessageBytes {
+		return NewFieldValidationError("message", fmt.Sprintf("queue message cannot exceed %d bytes on queue %q", qc.maxMessageBytes, qc.name.value))
+	}
+
+	return nil
+}
+
+// IsArchiving tells whether removed messages are kept in the archive, with
+// who completed them and their reservations.
+func (qc *QueueConfigEntity) IsArchiving() bool {
+	return qc.archive
+}
+
+func (qc *QueueConfigEntity) GetArchiveRetentionSeconds() int {
+	return qc.archiveRetentionSeconds
+}
+
+// GetArchiveRetention returns how long archived messages are kept, 0
+// meaning forever.
+func (qc *QueueConfigEntity) GetArchiveRetention() time.Duration {
+	return time.Duration(qc.archiveRetentionSeconds) * time.Second
+}
+
+// IsPaused tells whether consumption is stopped: publishes are accepted but
+// nothing can be reserved.
+func (qc *QueueConfigEntity) IsPaused() bool {
+	return qc.paused
+}
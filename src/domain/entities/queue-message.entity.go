@@ -1,18 +1,64 @@
 package DomainEntities
 
 import (
-	"errors"
+	"fmt"
+	"regexp"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
+const (
+	MaxQueueNameLength = 255
+	// MaxQueueMessageBytes bounds every message; queues may set a lower limit.
+	MaxQueueMessageBytes = 4 * 1024 * 1024
+	MaxGroupIdLength     = 128
+	queueNamePattern     = `^[A-Za-z0-9_.:\-]+$`
+)
+
+var queueNameRegexp = regexp.MustCompile(queueNamePattern)
+
+// ErrMessageIdTaken reports a message id stored by another tenant, as ids
+// are unique across tenants.
+var ErrMessageIdTaken = NewDomainError(ErrorKindConflict, "message_id_taken", "message id is taken by another tenant")
+
 type QueueNameEntity struct {
-	value string
+	tenant string
+	value  string
 }
 
 func NewQueueName(value string) (*QueueNameEntity, error) {
-	return &QueueNameEntity{value: value}, nil
+	return NewTenantQueueName("", value)
+}
+
+// NewTenantQueueName scopes the queue name to a tenant, so equal names of
+// different tenants never share messages.
+func NewTenantQueueName(tenant string, value string) (*QueueNameEntity, error) {
+
+	if value == "" {
+		return nil, NewFieldValidationError("queue_name", "queue name cannot be empty")
+	}
+
+	if len(value) > MaxQueueNameLength {
+		return nil, NewFieldValidationError("queue_name", fmt.Sprintf("queue name cannot be longer than %d characters", MaxQueueNameLength))
+	}
+
+	if !queueNameRegexp.MatchString(value) {
+		return nil, NewFieldValidationError("queue_name", fmt.Sprintf("queue name %q can only contain letters, digits, '_', '.', ':' and '-'", value))
+	}
+
+	return &QueueNameEntity{tenant: tenant, value: value}, nil
+}
+
+// RestoreTenantQueueName rebuilds a queue name read back from storage without
+// validating it again, so names stored under older rules stay readable.
+func RestoreTenantQueueName(tenant string, value string) QueueNameEntity {
+	return QueueNameEntity{tenant: tenant, value: value}
+}
+
+func (qn QueueNameEntity) GetTenant() string {
+	return qn.tenant
 }
 
 func (qn QueueNameEntity) GetValue() string {
@@ -24,35 +70,66 @@ type QueueMessageEntity struct {
 }
 
 func NewQueueMessage(value string) (*QueueMessageEntity, error) {
+
+	if value == "" {
+		return nil, NewFieldValidationError("message", "queue message cannot be empty")
+	}
+
+	if len(value) > MaxQueueMessageBytes {
+		return nil, NewFieldValidationError("message", fmt.Sprintf("queue message cannot exceed %d bytes", MaxQueueMessageBytes))
+	}
+
+	if !utf8.ValidString(value) {
+		return nil, NewFieldValidationError("message", "queue message must be valid UTF-8")
+	}
+
 	return &QueueMessageEntity{value: value}, nil
 }
 
+// RestoreQueueMessage rebuilds a stored message without validating it again.
+func RestoreQueueMessage(value string) QueueMessageEntity {
+	return QueueMessageEntity{value: value}
+}
+
 func (qm QueueMessageEntity) GetValue() string {
 	return qm.value
 }
 
+// GetSize returns the size of the message in bytes.
+func (qm QueueMessageEntity) GetSize() int {
+	return len(qm.value)
+}
+
 type QueueEntity struct {
 	id             string
 	name           QueueNameEntity
 	message        QueueMessageEntity
+	attributes     MessageAttributesEntity
+	groupId        *string
+	expiresAt      *time.Time
 	publishedAt    time.Time
 	reservedAt     *time.Time
 	reservedBy     *string
 	reservedCount  *int
 	reservedInfo   *string
 	reserveExpires time.Time
+	traceparent    *string
 }
 
 func NewQueue(
 	id *string,
 	name QueueNameEntity,
 	message QueueMessageEntity,
+	attributes MessageAttributesEntity,
+	groupId *string,
+	expiresAt *time.Time,
 	publishedAt time.Time,
 	reservedAt *time.Time,
 	reservedBy *string,
 	reservedCount *int,
 	reservedInfo *string,
 	reserveExpires time.Time,
+	traceparent *string,
 ) (*QueueEntity, error) {
 
 	if id == nil {
@@ -61,46 +138,92 @@ func NewQueue(
 	}
 
 	if name.value == "" {
-		return nil, errors.New("queue name cannot be empty")
+		return nil, NewFieldValidationError("queue_name", "queue name cannot be empty")
 	}
 
 	if message.value == "" {
-		return nil, errors.New("queue message cannot be empty")
+		return nil, NewFieldValidationError("message", "queue message cannot be empty")
+	}
+
+	if groupId != nil && (*groupId == "" || len(*groupId) > MaxGroupIdLength) {
+		return nil, NewFieldValidationError("group_id", fmt.Sprintf("groupId must have between 1 and %d characters", MaxGroupIdLength))
+	}
+
+	if expiresAt != nil && expiresAt.IsZero() {
+		return nil, NewValidationError("expiresAt cannot be zero")
 	}
 
 	if publishedAt.IsZero() {
-		return nil, errors.New("publishedAt cannot be zero")
+		return nil, NewValidationError("publishedAt cannot be zero")
 	}
 
 	if reservedAt != nil && reservedAt.IsZero() {
-		return nil, errors.New("reservedAt cannot be zero")
+		return nil, NewValidationError("reservedAt cannot be zero")
 	}
 
 	if reservedBy != nil && *reservedBy == "" {
-		return nil, errors.New("reservedBy cannot be empty")
+		return nil, NewValidationError("reservedBy cannot be empty")
 	}
 
 	if reservedCount != nil && *reservedCount < 0 {
-		return nil, errors.New("reservedCount cannot be negative")
+		return nil, NewValidationError("reservedCount cannot be negative")
 	}
 
 	if reservedInfo != nil && *reservedInfo == "" {
-		return nil, errors.New("reservedInfo cannot be empty")
+		return nil, NewValidationError("reservedInfo cannot be empty")
 	}
 
 	return &QueueEntity{
 		id:             *id,
 		name:           name,
 		message:        message,
+		attributes:     attributes,
+		groupId:        groupId,
+		expiresAt:      expiresAt,
 		publishedAt:    publishedAt,
 		reservedAt:     reservedAt,
 		reservedBy:     reservedBy,
 		reservedCount:  reservedCount,
 		reservedInfo:   reservedInfo,
 		reserveExpires: reserveExpires,
+		traceparent:    traceparent,
 	}, nil
 }
 
+// RestoreQueue rebuilds a stored message with its reservation state, without
+// validating it again.
+func RestoreQueue(
+	id string,
+	name QueueNameEntity,
+	message QueueMessageEntity,
+	attributes MessageAttributesEntity,
+	groupId *string,
+	expiresAt *time.Time,
+	publishedAt time.Time,
+	reservedAt *time.Time,
+	reservedBy *string,
+	reservedCount *int,
+	reservedInfo *string,
+	reserveExpires time.Time,
+	traceparent *string,
+) *QueueEntity {
+	return &QueueEntity{
+		id:             id,
+		name:           name,
+		message:        message,
+		attributes:     attributes,
+		groupId:        groupId,
+		expiresAt:      expiresAt,
+		publishedAt:    publishedAt,
+		reservedAt:     reservedAt,
+		reservedBy:     reservedBy,
+		reservedCount:  reservedCount,
+		reservedInfo:   reservedInfo,
+		reserveExpires: reserveExpires,
+		traceparent:    traceparent,
+	}
+}
+
 func (qm *QueueEntity) GetId() string {
 	return qm.id
 }
@@ -113,6 +236,21 @@ func (qm *QueueEntity) GetMessage() QueueMessageEntity {
 	return qm.message
 }
 
+func (qm *QueueEntity) GetAttributes() MessageAttributesEntity {
+	return qm.attributes
+}
+
+// GetGroupId returns the FIFO group of the message. Messages of a group are
+// reserved one at a time, in publish order.
+func (qm *QueueEntity) GetGroupId() *string {
+	return qm.groupId
+}
+
+// GetExpiresAt returns when the message stops being delivered, nil if never.
+func (qm *QueueEntity) GetExpiresAt() *time.Time {
+	return qm.expiresAt
+}
+
 func (qm *QueueEntity) GetPublishedAt() time.Time {
 	return qm.publishedAt
 }
@@ -136,3 +274,35 @@ func (qm *QueueEntity) GetReservedInfo() *string {
 func (qm *QueueEntity) GetReserveExpires() time.Time {
 	return qm.reserveExpires
 }
+
+// GetTraceparent returns the W3C trace context of the publish, if traced.
+func (qm *QueueEntity) GetTraceparent() *string {
+	return qm.traceparent
+}
+
+type MessageState string
+
+const (
+	MessageStateVisible  MessageState = "visible"
+	MessageStateInFlight MessageState = "in_flight"
+	MessageStateDelayed  MessageState = "delayed"
+	MessageStateExpired  MessageState = "expired"
+)
+
+// GetState tells, at now, whether the message can be reserved, is reserved,
+// is not visible yet without ever having been reserved, or has expired.
+func (qm *QueueEntity) GetState(now time.Time) MessageState {
+	if qm.expiresAt != nil && !qm.expiresAt.After(now) {
+		return MessageStateExpired
+	}
+
+	if qm.reserveExpires.Before(now) {
+		return MessageStateVisible
+	}
+
+	if qm.reservedAt == nil {
+		return MessageStateDelayed
+	}
+
+	return MessageStateInFlight
+}
@@ -0,0 +1,74 @@
+package DomainEntities
+
+import (
+	"time"
+)
+
+type QueueStatsEntity struct {
+	name              QueueNameEntity
+	messages          int64
+	visible           int64
+	inFlight          int64
+	delayed           int64
+	bytes             int64
+	oldestPublishedAt *time.Time
+	paused            bool
+}
+
+// NewQueueStats describes the unexpired messages of a queue: visible ones can
+// be reserved, in flight ones are reserved, delayed ones become visible later
+// without having been reserved, and oldestPublishedAt is the publish time of
+// the oldest visible one.
+func NewQueueStats(
+	name QueueNameEntity,
+	messages int64,
+	visible int64,
+	inFlight int64,
+	delayed int64,
+	bytes int64,
+	oldestPublishedAt *time.Time,
+	paused bool,
+) *QueueStatsEntity {
+	return &QueueStatsEntity{
+		name:              name,
+		messages:          messages,
+		visible:           visible,
+		inFlight:          inFlight,
+		delayed:           delayed,
+		bytes:             bytes,
+		oldestPublishedAt: oldestPublishedAt,
+		paused:            paused,
+	}
+}
+
+func (qs *QueueStatsEntity) GetName() QueueNameEntity {
+	return qs.name
+}
+
+func (qs *QueueStatsEntity) GetMessages() int64 {
+	return qs.messages
+}
+
+func (qs *QueueStatsEntity) GetVisible() int64 {
+	return qs.visible
+}
+
+func (qs *QueueStatsEntity) GetInFlight() int64 {
+	return qs.inFlight
+}
+
+func (qs *QueueStatsEntity) GetDelayed() int64 {
+	return qs.delayed
+}
+
+func (qs *QueueStatsEntity) GetBytes() int64 {
+	return qs.bytes
+}
+
+func (qs *QueueStatsEntity) GetOldestPublishedAt() *time.Time {
+	return qs.oldestPublishedAt
+}
+
+func (qs *QueueStatsEntity) IsPaused() bool {
+	return qs.paused
+}
@@ -0,0 +1,208 @@
+package DomainEntities
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+var ErrScheduleNotFound = NewNotFoundError("schedule_not_found", "schedule not found")
+
+type MissedRunPolicy string
+
+const (
+	// MissedRunPolicyRunOnce publishes a single message for all the runs
+	// missed while the scheduler was down.
+	MissedRunPolicyRunOnce MissedRunPolicy = "run_once"
+	// MissedRunPolicySkip publishes nothing for runs later than
+	// ScheduleMissedRunGrace.
+	MissedRunPolicySkip MissedRunPolicy = "skip"
+)
+
+// ScheduleMissedRunGrace is how late a run may be and still not count as missed.
+const ScheduleMissedRunGrace = time.Minute
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// SchedulePayloadData is available to payload templates, e.g.
+// {"tick": "{{.ScheduledAt.Format "2006-01-02T15:04:05Z07:00"}}"}.
+type SchedulePayloadData struct {
+	Name        string
+	QueueName   string
+	ScheduledAt time.Time
+}
+
+type ScheduleEntity struct {
+	id              string
+	name            string
+	cronExpression  string
+	cronSchedule    cron.Schedule
+	timeZone        *time.Location
+	queueName       QueueNameEntity
+	payloadTemplate *template.Template
+	payload         string
+	attributes      MessageAttributesEntity
+	missedRunPolicy MissedRunPolicy
+	paused          bool
+	nextRunAt       time.Time
+	lastRunAt       *time.Time
+	createdAt       time.Time
+}
+
+// NewSchedule publishes payload, rendered as a text/template of
+// SchedulePayloadData, into queueName at the times of cronExpression, a
+// standard five field expression or a descriptor such as @hourly,
+// evaluated in timeZone. A zero nextRunAt is the first run after createdAt.
+func NewSchedule(
+	id *string,
+	name string,
+	cronExpression string,
+	timeZone string,
+	queueName QueueNameEntity,
+	payload string,
+	attributes MessageAttributesEntity,
+	missedRunPolicy MissedRunPolicy,
+	paused bool,
+	nextRunAt time.Time,
+	lastRunAt *time.Time,
+	createdAt time.Time,
+) (*ScheduleEntity, error) {
+
+	if id == nil {
+		newUuid := uuid.New().String()
+		id = &newUuid
+	}
+
+	if name == "" || len(name) > 255 {
+		return nil, NewValidationError("schedule name must have between 1 and 255 characters")
+	}
+
+	cronSchedule, err := cronParser.Parse(cronExpression)
+	if err != nil {
+		return nil, NewValidationError("invalid cron expression: " + err.Error())
+	}
+
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, NewValidationError("invalid time zone: " + timeZone)
+	}
+
+	if queueName.value == "" {
+		return nil, NewValidationError("queue name cannot be empty")
+	}
+
+	payloadTemplate, err := template.New(name).Option("missingkey=error").Parse(payload)
+	if err != nil {
+		return nil, NewValidationError("invalid payload template: " + err.Error())
+	}
+
+	if missedRunPolicy == "" {
+		missedRunPolicy = MissedRunPolicyRunOnce
+	}
+
+	if missedRunPolicy != MissedRunPolicyRunOnce && missedRunPolicy != MissedRunPolicySkip {
+		return nil, NewValidationError("missed run policy must be run_once or skip")
+	}
+
+	if createdAt.IsZero() {
+		return nil, NewValidationError("createdAt cannot be zero")
+	}
+
+	if nextRunAt.IsZero() {
+		nextRunAt = cronSchedule.Next(createdAt.In(location))
+	}
+
+	return &ScheduleEntity{
+		id:              *id,
+		name:            name,
+		cronExpression:  cronExpression,
+		cronSchedule:    cronSchedule,
+		timeZone:        location,
+		queueName:       queueName,
+		payloadTemplate: payloadTemplate,
+		payload:         payload,
+		attributes:      attributes,
+		missedRunPolicy: missedRunPolicy,
+		paused:          paused,
+		nextRunAt:       nextRunAt,
+		lastRunAt:       lastRunAt,
+		createdAt:       createdAt,
+	}, nil
+}
+
+func (s *ScheduleEntity) GetId() string {
+	return s.id
+}
+
+func (s *ScheduleEntity) GetName() string {
+	return s.name
+}
+
+func (s *ScheduleEntity) GetCronExpression() string {
+	return s.cronExpression
+}
+
+func (s *ScheduleEntity) GetTimeZone() string {
+	return s.timeZone.String()
+}
+
+func (s *ScheduleEntity) GetQueueName() QueueNameEntity {
+	return s.queueName
+}
+
+func (s *ScheduleEntity) GetPayload() string {
+	return s.payload
+}
+
+func (s *ScheduleEntity) GetAttributes() MessageAttributesEntity {
+	return s.attributes
+}
+
+func (s *ScheduleEntity) GetMissedRunPolicy() MissedRunPolicy {
+	return s.missedRunPolicy
+}
+
+func (s *ScheduleEntity) IsPaused() bool {
+	return s.paused
+}
+
+func (s *ScheduleEntity) GetNextRunAt() time.Time {
+	return s.nextRunAt
+}
+
+func (s *ScheduleEntity) GetLastRunAt() *time.Time {
+	return s.lastRunAt
+}
+
+func (s *ScheduleEntity) GetCreatedAt() time.Time {
+	return s.createdAt
+}
+
+// NextRunAfter returns the first run after t, following the daylight saving
+// changes of the schedule time zone.
+func (s *ScheduleEntity) NextRunAfter(t time.Time) time.Time {
+	return s.cronSchedule.Next(t.In(s.timeZone))
+}
+
+// RenderPayload renders the payload template for the run at scheduledAt.
+func (s *ScheduleEntity) RenderPayload(scheduledAt time.Time) (string, error) {
+	var payload strings.Builder
+
+	err := s.payloadTemplate.Execute(&payload, SchedulePayloadData{
+		Name:        s.name,
+		QueueName:   s.queueName.GetValue(),
+		ScheduledAt: scheduledAt.In(s.timeZone),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return payload.String(), nil
+}
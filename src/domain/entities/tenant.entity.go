@@ -0,0 +1,48 @@
+package DomainEntities
+
+var ErrTenantQuotaExceeded = NewDomainError(ErrorKindForbidden, "tenant_quota_exceeded", "tenant quota exceeded")
+
+type TenantEntity struct {
+	id          string
+	maxMessages int64
+	maxBytes    int64
+}
+
+func NewTenant(id string, maxMessages int64, maxBytes int64) (*TenantEntity, error) {
+
+	if len(id) > 64 {
+		return nil, NewValidationError("tenant id cannot be longer than 64 characters")
+	}
+
+	if maxMessages < 0 {
+		return nil, NewValidationError("tenant maxMessages cannot be negative")
+	}
+
+	if maxBytes < 0 {
+		return nil, NewValidationError("tenant maxBytes cannot be negative")
+	}
+
+	return &TenantEntity{
+		id:          id,
+		maxMessages: maxMessages,
+		maxBytes:    maxBytes,
+	}, nil
+}
+
+func (t TenantEntity) GetId() string {
+	return t.id
+}
+
+// GetMaxMessages returns the maximum number of stored messages, 0 meaning unlimited.
+func (t TenantEntity) GetMaxMessages() int64 {
+	return t.maxMessages
+}
+
+// GetMaxBytes returns the maximum size of stored payloads in bytes, 0 meaning unlimited.
+func (t TenantEntity) GetMaxBytes() int64 {
+	return t.maxBytes
+}
+
+func (t TenantEntity) HasQuota() bool {
+	return t.maxMessages > 0 || t.maxBytes > 0
+}
@@ -0,0 +1,115 @@
+package DomainEntities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrTopicNotFound = NewNotFoundError("topic_not_found", "topic not found")
+
+type TopicSubscriptionEntity struct {
+	id        string
+	topic     string
+	queueName QueueNameEntity
+	filters   []AttributeFilterEntity
+	createdAt time.Time
+}
+
+// NewTopicSubscription delivers a copy of every message published on topic,
+// whose attributes match all filters, to queueName.
+func NewTopicSubscription(
+	id *string,
+	topic string,
+	queueName QueueNameEntity,
+	filters []AttributeFilterEntity,
+	createdAt time.Time,
+) (*TopicSubscriptionEntity, error) {
+
+	if id == nil {
+		newUuid := uuid.New().String()
+		id = &newUuid
+	}
+
+	if topic == "" || len(topic) > 255 {
+		return nil, NewValidationError("topic must have between 1 and 255 characters")
+	}
+
+	if queueName.value == "" {
+		return nil, NewValidationError("queue name cannot be empty")
+	}
+
+	if createdAt.IsZero() {
+		return nil, NewValidationError("createdAt cannot be zero")
+	}
+
+	return &TopicSubscriptionEntity{
+		id:        *id,
+		topic:     topic,
+		queueName: queueName,
+		filters:   filters,
+		createdAt: createdAt,
+	}, nil
+}
+
+func (ts *TopicSubscriptionEntity) GetId() string {
+	return ts.id
+}
+
+func (ts *TopicSubscriptionEntity) GetTopic() string {
+	return ts.topic
+}
+
+func (ts *TopicSubscriptionEntity) GetQueueName() QueueNameEntity {
+	return ts.queueName
+}
+
+func (ts *TopicSubscriptionEntity) GetFilters() []AttributeFilterEntity {
+	return ts.filters
+}
+
+func (ts *TopicSubscriptionEntity) GetCreatedAt() time.Time {
+	return ts.createdAt
+}
+
+func (ts *TopicSubscriptionEntity) Matches(attributes MessageAttributesEntity) bool {
+	for _, filter := range ts.filters {
+		if !filter.Matches(attributes) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TopicEntity exists as long as it has subscriptions.
+type TopicEntity struct {
+	tenant        string
+	name          string
+	subscriptions []TopicSubscriptionEntity
+}
+
+func NewTopic(tenant string, name string, subscriptions []TopicSubscriptionEntity) (*TopicEntity, error) {
+
+	if name == "" || len(name) > 255 {
+		return nil, NewValidationError("topic must have between 1 and 255 characters")
+	}
+
+	return &TopicEntity{
+		tenant:        tenant,
+		name:          name,
+		subscriptions: subscriptions,
+	}, nil
+}
+
+func (t *TopicEntity) GetTenant() string {
+	return t.tenant
+}
+
+func (t *TopicEntity) GetName() string {
+	return t.name
+}
+
+func (t *TopicEntity) GetSubscriptions() []TopicSubscriptionEntity {
+	return t.subscriptions
+}
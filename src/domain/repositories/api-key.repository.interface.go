@@ -0,0 +1,15 @@
+package DomainRepositories
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+)
+
+type ApiKeyRepositoryInterface interface {
+	ListApiKeys(ctx context.Context) ([]DomainEntities.ApiKeyEntity, error)
+	// GetApiKeyByHash returns nil when no stored key has keyHash
+	GetApiKeyByHash(ctx context.Context, keyHash string) (*DomainEntities.ApiKeyEntity, error)
+	// SaveApiKey fails with a duplicate key error when the name is taken
+	SaveApiKey(ctx context.Context, apiKey DomainEntities.ApiKeyEntity) error
+	RemoveApiKey(ctx context.Context, name string) (bool, error)
+}
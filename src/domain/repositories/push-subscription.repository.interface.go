@@ -0,0 +1,13 @@
+package DomainRepositories
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+)
+
+type PushSubscriptionRepositoryInterface interface {
+	ListPushSubscriptions(ctx context.Context) ([]DomainEntities.PushSubscriptionEntity, error)
+	GetPushSubscriptions(ctx context.Context, queueName DomainEntities.QueueNameEntity) ([]DomainEntities.PushSubscriptionEntity, error)
+	SavePushSubscription(ctx context.Context, subscription DomainEntities.PushSubscriptionEntity) error
+	RemovePushSubscription(ctx context.Context, queueName DomainEntities.QueueNameEntity, id string) (bool, error)
+}
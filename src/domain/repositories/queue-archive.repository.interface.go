@@ -0,0 +1,23 @@
+package DomainRepositories
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	"time"
+)
+
+type QueueArchiveRepositoryInterface interface {
+	SearchArchive(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		filter DomainEntities.ArchiveFilterEntity,
+		cursor *DomainEntities.MessageCursorEntity,
+		limit int,
+	) ([]DomainEntities.ArchivedMessageEntity, error)
+	RemoveArchivedBefore(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		completedBefore time.Time,
+		limit int,
+	) (int64, error)
+}
@@ -0,0 +1,13 @@
+package DomainRepositories
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+)
+
+type QueueConfigRepositoryInterface interface {
+	GetConfig(ctx context.Context, queueName DomainEntities.QueueNameEntity) (*DomainEntities.QueueConfigEntity, error)
+	SaveConfig(ctx context.Context, config DomainEntities.QueueConfigEntity) error
+	SetPaused(ctx context.Context, queueName DomainEntities.QueueNameEntity, paused bool) error
+	ListConfigs(ctx context.Context) ([]DomainEntities.QueueConfigEntity, error)
+}
@@ -1,16 +1,31 @@
 package DomainRepositories
 
 import (
+	"context"
 	DomainEntities "lean-queue/src/domain/entities"
 	"time"
 )
 
 type QueueRepositoryInterface interface {
-	Save(message DomainEntities.QueueEntity) error
-	GetById(id string) (*DomainEntities.QueueEntity, error)
+	Save(ctx context.Context, tenant DomainEntities.TenantEntity, message DomainEntities.QueueEntity) error
+	SaveMany(ctx context.Context, tenant DomainEntities.TenantEntity, messages []DomainEntities.QueueEntity) error
+	SaveManyNew(ctx context.Context, tenant DomainEntities.TenantEntity, messages []DomainEntities.QueueEntity) (saved int64, err error)
+	SaveDeduplicated(
+		ctx context.Context,
+		tenant DomainEntities.TenantEntity,
+		message DomainEntities.QueueEntity,
+		deduplicationId DomainEntities.DeduplicationIdEntity,
+		now time.Time,
+		expiresAt time.Time,
+	) (messageId string, duplicate bool, err error)
+	RemoveExpiredDeduplications(ctx context.Context, now time.Time, limit int) (int64, error)
+	GetById(ctx context.Context, tenant string, id string) (*DomainEntities.QueueEntity, error)
+	GetReservations(ctx context.Context, tenant string, id string) ([]DomainEntities.MessageReservationEntity, error)
 	GetAndReserveMessages(
+		ctx context.Context,
 		queueName DomainEntities.QueueNameEntity,
 		limit int,
+		filters []DomainEntities.AttributeFilterEntity,
 		messagesBefore time.Time,
 		updateReservedAt time.Time,
 		updateReservedBy string,
@@ -18,8 +33,63 @@ type QueueRepositoryInterface interface {
 		updateReservedExpires *time.Time,
 	) ([]DomainEntities.QueueEntity, error)
 	GetMessages(
+		ctx context.Context,
 		queueName DomainEntities.QueueNameEntity,
+		filter DomainEntities.MessageListFilterEntity,
+		cursor *DomainEntities.MessageCursorEntity,
 		limit int,
+		now time.Time,
 	) ([]DomainEntities.QueueEntity, error)
-	RemoveById(id string) error
+	CompleteById(ctx context.Context, tenant string, id string, completedBy *string, completedAt time.Time) error
+	ReleaseMessage(ctx context.Context, tenant string, id string, reservedBy string, visibleAt time.Time) (bool, error)
+	PurgeMessages(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		visibleAt *time.Time,
+		publishedBefore *time.Time,
+		limit int,
+	) (int64, error)
+	GetQueueStats(ctx context.Context, queueName DomainEntities.QueueNameEntity, now time.Time) (*DomainEntities.QueueStatsEntity, error)
+	ListQueueStats(ctx context.Context, tenant string, now time.Time) ([]DomainEntities.QueueStatsEntity, error)
+	RemovePublishedBefore(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		publishedBefore time.Time,
+		limit int,
+	) ([]string, error)
+	TrimToLength(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		maxLength int,
+		limit int,
+	) ([]string, error)
+	MoveExhaustedMessages(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		maxReceives int,
+		deadLetterQueue DomainEntities.QueueNameEntity,
+		visibleBefore time.Time,
+		limit int,
+	) ([]string, error)
+	RedriveMessages(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		target DomainEntities.QueueNameEntity,
+		visibleBefore time.Time,
+		limit int,
+	) ([]string, error)
+	GetQueuesWithExpiredMessages(ctx context.Context, now time.Time, limit int) ([]DomainEntities.QueueNameEntity, error)
+	RemoveExpired(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		now time.Time,
+		limit int,
+	) ([]string, error)
+	MoveExpiredMessages(
+		ctx context.Context,
+		queueName DomainEntities.QueueNameEntity,
+		deadLetterQueue DomainEntities.QueueNameEntity,
+		now time.Time,
+		limit int,
+	) ([]string, error)
 }
@@ -0,0 +1,16 @@
+package DomainRepositories
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	"time"
+)
+
+type ScheduleRepositoryInterface interface {
+	ListSchedules(ctx context.Context, tenant string) ([]DomainEntities.ScheduleEntity, error)
+	GetSchedule(ctx context.Context, tenant string, name string) (*DomainEntities.ScheduleEntity, error)
+	SaveSchedule(ctx context.Context, schedule DomainEntities.ScheduleEntity) error
+	RemoveSchedule(ctx context.Context, tenant string, name string) (bool, error)
+	GetDueSchedules(ctx context.Context, now time.Time, limit int) ([]DomainEntities.ScheduleEntity, error)
+	SetScheduleRun(ctx context.Context, id string, lastRunAt *time.Time, nextRunAt time.Time) error
+}
@@ -0,0 +1,13 @@
+package DomainRepositories
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+)
+
+type TopicRepositoryInterface interface {
+	GetTopic(ctx context.Context, tenant string, topic string) (*DomainEntities.TopicEntity, error)
+	ListTopics(ctx context.Context, tenant string) ([]DomainEntities.TopicEntity, error)
+	SaveSubscription(ctx context.Context, tenant string, subscription DomainEntities.TopicSubscriptionEntity) error
+	RemoveSubscription(ctx context.Context, tenant string, topic string, id string) (bool, error)
+}
@@ -0,0 +1,54 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+)
+
+type createApiKeyController struct {
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+}
+
+func NewCreateApiKeyController(
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *createApiKeyController {
+	return &createApiKeyController{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+func (controller *createApiKeyController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewCreateApiKeyUsecase(
+		controller.apiKeyRepository,
+	)
+
+	type requestBody struct {
+		Name   string `json:"name"`
+		Tenant string `json:"tenant"`
+		Admin  bool   `json:"admin"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	apiKey, key, err := usecase.Handle(r.Context(), body.Name, body.Tenant, body.Admin)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	outputObject := apiKeyOutput(*apiKey)
+	outputObject["key"] = key
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(outputObject)
+}
@@ -0,0 +1,73 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type createPushSubscriptionController struct {
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface
+	allowedHosts               []string
+}
+
+func NewCreatePushSubscriptionController(
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface,
+	allowedHosts []string,
+) *createPushSubscriptionController {
+	return &createPushSubscriptionController{
+		pushSubscriptionRepository: pushSubscriptionRepository,
+		allowedHosts:               allowedHosts,
+	}
+}
+
+func (controller *createPushSubscriptionController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewCreatePushSubscriptionUsecase(
+		controller.pushSubscriptionRepository,
+		controller.allowedHosts,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	type requestBody struct {
+		Url            string `json:"url"`
+		Secret         string `json:"secret"`
+		MaxConcurrency int    `json:"max_concurrency"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	subscription, err := usecase.Handle(
+		r.Context(),
+		InfrastructureMiddlewares.TenantFromRequest(r),
+		queueName,
+		body.Url,
+		body.Secret,
+		body.MaxConcurrency,
+		body.TimeoutSeconds,
+	)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	outputObject := pushSubscriptionOutput(*subscription)
+	outputObject["secret"] = subscription.GetSecret()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(outputObject)
+}
@@ -0,0 +1,117 @@
+package InfrastructureControllers
+
+import (
+	"bufio"
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type exportQueuesController struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+	batchSize       int
+}
+
+func NewExportQueuesController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	batchSize int,
+) *exportQueuesController {
+	return &exportQueuesController{
+		queueRepository: queueRepository,
+		batchSize:       batchSize,
+	}
+}
+
+// Handle streams the messages of the queue_name queues, every queue when
+// none is given, as newline-delimited JSON, one message per line.
+func (controller *exportQueuesController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewExportQueuesUsecase(
+		controller.queueRepository,
+		controller.batchSize,
+	)
+
+	var queueNames []string
+	for _, queueNameParam := range r.URL.Query()["queue_name"] {
+		for _, queueName := range strings.Split(queueNameParam, ",") {
+			if queueName != "" {
+				queueNames = append(queueNames, queueName)
+			}
+		}
+	}
+
+	// Large queues take longer than the server write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	var exported int64
+
+	err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueNames, func(message DomainEntities.QueueEntity) error {
+		if exported == 0 {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		exported++
+
+		var reservedAtStr *string
+		if message.GetReservedAt() != nil {
+			reservedAtStrC := message.GetReservedAt().UTC().Format("2006-01-02 15:04:05.999999")
+			reservedAtStr = &reservedAtStrC
+		}
+
+		var expiresAtStr *string
+		if message.GetExpiresAt() != nil {
+			expiresAtStrC := message.GetExpiresAt().UTC().Format("2006-01-02 15:04:05.999999")
+			expiresAtStr = &expiresAtStrC
+		}
+
+		return encoder.Encode(map[string]interface{}{
+			"id":              message.GetId(),
+			"queue_name":      message.GetName().GetValue(),
+			"message":         message.GetMessage().GetValue(),
+			"attributes":      message.GetAttributes().GetValues(),
+			"group_id":        message.GetGroupId(),
+			"expires_at":      expiresAtStr,
+			"published_at":    message.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+			"reserved_at":     reservedAtStr,
+			"reserved_by":     message.GetReservedBy(),
+			"reserved_count":  message.GetReservedCount(),
+			"reserved_info":   message.GetReservedInfo(),
+			"reserve_expires": message.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+			"traceparent":     message.GetTraceparent(),
+		})
+	})
+
+	if err != nil && exported == 0 {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	// Once streaming the status is sent, so the connection is cut instead
+	// for the client to see a failed rather than a shorter export
+	if err != nil {
+		InfrastructureLogger.FromContext(r.Context()).Error("export failed", "error", err, "exported", exported)
+		panic(http.ErrAbortHandler)
+	}
+
+	if exported == 0 {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	InfrastructureLogger.FromContext(r.Context()).Info("exported queues",
+		"queue_names", queueNames,
+		"exported", exported,
+	)
+}
@@ -0,0 +1,60 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type extendMessageReservationController struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewExtendMessageReservationController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *extendMessageReservationController {
+	return &extendMessageReservationController{
+		queueRepository: queueRepository,
+	}
+}
+
+func (controller *extendMessageReservationController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewExtendMessageReservationUsecase(
+		controller.queueRepository,
+	)
+
+	vars := mux.Vars(r)
+	messageId := vars["id"]
+
+	type requestBody struct {
+		ReservedBy       string `json:"reserved_by"`
+		ReserveBySeconds int    `json:"reserve_by_seconds"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	reserveExpires, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), messageId, body.ReservedBy, body.ReserveBySeconds)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":         "Reservation extended successfully",
+		"message_id":      messageId,
+		"reserve_expires": reserveExpires.UTC().Format("2006-01-02 15:04:05.999999"),
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	ApplicationUsecases "lean-queue/src/application/usecases"
 	DomainRepositories "lean-queue/src/domain/repositories"
-	"log"
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
 	"net/http"
 	"strconv"
 )
@@ -33,11 +35,16 @@ func (controller *getAndReserveNextMessagesController) Handle(w http.ResponseWri
 	var reservedInfo string = r.URL.Query().Get("reserved_info")
 	var reserveBySeconds int = 60
 	if r.URL.Query().Get("reserve_by_seconds") != "" {
-		reserveBySeconds, _ = strconv.Atoi(r.URL.Query().Get("reserve_by_seconds"))
+		var err error
+		reserveBySeconds, err = strconv.Atoi(r.URL.Query().Get("reserve_by_seconds"))
+		if err != nil {
+			InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("reserve_by_seconds", err.Error()))
+			return
+		}
 	}
 
 	if queueName == "" {
-		http.Error(w, "Missing queue_name parameter", http.StatusBadRequest)
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
 		return
 	}
 
@@ -47,36 +54,54 @@ func (controller *getAndReserveNextMessagesController) Handle(w http.ResponseWri
 	}
 
 	if reservedBy == "" {
-		http.Error(w, "Missing reserved_by parameter", http.StatusBadRequest)
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("reserved_by", "missing"))
 		return
 	}
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil {
-		http.Error(w, "Invalid limit parameter: "+err.Error(), http.StatusBadRequest)
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("limit", err.Error()))
 		return
 	}
 
-	messages, err := usecase.Handle(queueName, limit, reservedBy, reserveBySeconds, &reservedInfo)
+	messages, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName, limit, r.URL.Query()["filter"], reservedBy, reserveBySeconds, &reservedInfo)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		InfrastructureResponses.WriteError(w, r, err)
 		return
 	}
 
-	log.Printf("Reserved %+v", messages)
+	messageIds := make([]string, len(messages))
+	for i, message := range messages {
+		messageIds[i] = message.GetId()
+	}
+	InfrastructureLogger.FromContext(r.Context()).Debug("reserved messages",
+		"queue_name", queueName,
+		"reserved_by", reservedBy,
+		"message_ids", messageIds,
+	)
 
 	outputObject := make([]map[string]interface{}, len(messages))
 	for i, message := range messages {
+		var expiresAtStr *string
+		if message.GetExpiresAt() != nil {
+			expiresAtStrC := message.GetExpiresAt().UTC().Format("2006-01-02 15:04:05.999999")
+			expiresAtStr = &expiresAtStrC
+		}
+
 		outputObject[i] = map[string]interface{}{
 			"id":              message.GetId(),
 			"queue_name":      message.GetName().GetValue(),
 			"message":         message.GetMessage().GetValue(),
+			"attributes":      message.GetAttributes().GetValues(),
+			"group_id":        message.GetGroupId(),
+			"expires_at":      expiresAtStr,
 			"published_at":    message.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999"),
 			"reserved_at":     message.GetReservedAt().UTC().Format("2006-01-02 15:04:05.999999"),
 			"reserved_by":     message.GetReservedBy(),
 			"reserved_count":  message.GetReservedCount(),
 			"reserved_info":   message.GetReservedInfo(),
 			"reserve_expires": message.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+			"traceparent":     message.GetTraceparent(),
 		}
 	}
 
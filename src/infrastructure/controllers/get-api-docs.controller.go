@@ -0,0 +1,19 @@
+package InfrastructureControllers
+
+import (
+	InfrastructureDocs "lean-queue/src/infrastructure/docs"
+	"net/http"
+)
+
+type getApiDocsController struct{}
+
+func NewGetApiDocsController() *getApiDocsController {
+	return &getApiDocsController{}
+}
+
+// Handle serves the page rendering the OpenAPI document.
+func (controller *getApiDocsController) Handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(InfrastructureDocs.Page)
+}
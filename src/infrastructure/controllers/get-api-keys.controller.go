@@ -0,0 +1,53 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+)
+
+type getApiKeysController struct {
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+}
+
+func NewGetApiKeysController(
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *getApiKeysController {
+	return &getApiKeysController{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+func (controller *getApiKeysController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetApiKeysUsecase(
+		controller.apiKeyRepository,
+	)
+
+	apiKeys, err := usecase.Handle(r.Context())
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	outputObject := make([]map[string]interface{}, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		outputObject[i] = apiKeyOutput(apiKey)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(outputObject)
+}
+
+// apiKeyOutput leaves the key out; it is only returned on creation.
+func apiKeyOutput(apiKey DomainEntities.ApiKeyEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       apiKey.GetName(),
+		"tenant":     apiKey.GetTenant(),
+		"admin":      apiKey.IsAdmin(),
+		"created_at": apiKey.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	}
+}
@@ -0,0 +1,82 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type getMessageController struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewGetMessageController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *getMessageController {
+	return &getMessageController{
+		queueRepository: queueRepository,
+	}
+}
+
+func (controller *getMessageController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetMessageUsecase(
+		controller.queueRepository,
+	)
+
+	vars := mux.Vars(r)
+	messageId := vars["id"]
+
+	message, reservations, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), messageId)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	var expiresAtStr *string
+	if message.GetExpiresAt() != nil {
+		expiresAtStrC := message.GetExpiresAt().UTC().Format("2006-01-02 15:04:05.999999")
+		expiresAtStr = &expiresAtStrC
+	}
+
+	var reservedAtStr *string
+	if message.GetReservedAt() != nil {
+		reservedAtStrC := message.GetReservedAt().UTC().Format("2006-01-02 15:04:05.999999")
+		reservedAtStr = &reservedAtStrC
+	}
+
+	reservationsOutput := make([]map[string]interface{}, len(reservations))
+	for i, reservation := range reservations {
+		reservationsOutput[i] = map[string]interface{}{
+			"reserved_at":     reservation.GetReservedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+			"reserved_by":     reservation.GetReservedBy(),
+			"reserved_info":   reservation.GetReservedInfo(),
+			"reserve_expires": reservation.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":              message.GetId(),
+		"queue_name":      message.GetName().GetValue(),
+		"message":         message.GetMessage().GetValue(),
+		"attributes":      message.GetAttributes().GetValues(),
+		"group_id":        message.GetGroupId(),
+		"expires_at":      expiresAtStr,
+		"state":           message.GetState(time.Now()),
+		"published_at":    message.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+		"reserved_at":     reservedAtStr,
+		"reserved_by":     message.GetReservedBy(),
+		"reserved_count":  message.GetReservedCount(),
+		"reserved_info":   message.GetReservedInfo(),
+		"reserve_expires": message.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+		"traceparent":     message.GetTraceparent(),
+		"reservations":    reservationsOutput,
+	})
+}
@@ -3,9 +3,14 @@ package InfrastructureControllers
 import (
 	"encoding/json"
 	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
 	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -34,7 +39,7 @@ func (controller *getMessagesOnQueueController) Handle(w http.ResponseWriter, r
 	limitInt, _ := strconv.Atoi(limitStr)
 
 	if queueName == "" {
-		http.Error(w, "Missing queue_name parameter", http.StatusBadRequest)
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
 		return
 	}
 
@@ -45,13 +50,63 @@ func (controller *getMessagesOnQueueController) Handle(w http.ResponseWriter, r
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil {
-		http.Error(w, "Invalid limit parameter: "+err.Error(), http.StatusBadRequest)
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("limit", err.Error()))
 		return
 	}
 
-	messages, err := usecase.Handle(queueName, limit)
+	query := r.URL.Query()
+
+	var states []DomainEntities.MessageState
+	for _, stateParam := range query["state"] {
+		for _, state := range strings.Split(stateParam, ",") {
+			if state != "" {
+				states = append(states, DomainEntities.MessageState(state))
+			}
+		}
+	}
+
+	var reservedBy *string
+	if query.Has("reserved_by") {
+		reservedByStr := query.Get("reserved_by")
+		reservedBy = &reservedByStr
+	}
+
+	publishedAfter, err := timeParameter(query.Get("published_after"))
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("published_after", err.Error()))
+		return
+	}
+
+	publishedBefore, err := timeParameter(query.Get("published_before"))
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("published_before", err.Error()))
+		return
+	}
+
+	minReservedCount := 0
+	if query.Get("min_reserved_count") != "" {
+		minReservedCount, err = strconv.Atoi(query.Get("min_reserved_count"))
+		if err != nil {
+			InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("min_reserved_count", err.Error()))
+			return
+		}
+	}
+
+	sort := query.Get("sort")
+	if sort != "" && sort != "asc" && sort != "desc" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("sort", "must be asc or desc"))
+		return
+	}
+
+	filter, err := DomainEntities.NewMessageListFilter(states, reservedBy, publishedAfter, publishedBefore, minReservedCount, sort == "desc")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	messages, nextCursor, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName, *filter, query.Get("cursor"), limit)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
 		return
 	}
 
@@ -63,20 +118,48 @@ func (controller *getMessagesOnQueueController) Handle(w http.ResponseWriter, r
 			reservedAtStr = &reservedAtStrC
 		}
 
+		var expiresAtStr *string
+		if message.GetExpiresAt() != nil {
+			expiresAtStrC := message.GetExpiresAt().UTC().Format("2006-01-02 15:04:05.999999")
+			expiresAtStr = &expiresAtStrC
+		}
+
 		outputObject[i] = map[string]interface{}{
 			"id":              message.GetId(),
 			"queue_name":      message.GetName().GetValue(),
 			"message":         message.GetMessage().GetValue(),
+			"attributes":      message.GetAttributes().GetValues(),
+			"group_id":        message.GetGroupId(),
+			"expires_at":      expiresAtStr,
 			"published_at":    message.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999"),
 			"reserved_at":     reservedAtStr,
 			"reserved_by":     message.GetReservedBy(),
 			"reserved_count":  message.GetReservedCount(),
 			"reserved_info":   message.GetReservedInfo(),
 			"reserve_expires": message.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+			"traceparent":     message.GetTraceparent(),
 		}
 	}
 
+	// The body stays a plain array, so the next page cursor goes in a header
+	if nextCursor != "" {
+		w.Header().Set("X-Next-Cursor", nextCursor)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(outputObject)
 }
+
+// timeParameter parses an optional RFC 3339 query parameter.
+func timeParameter(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
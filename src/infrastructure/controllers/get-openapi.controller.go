@@ -0,0 +1,18 @@
+package InfrastructureControllers
+
+import (
+	InfrastructureDocs "lean-queue/src/infrastructure/docs"
+	"net/http"
+)
+
+type getOpenApiController struct{}
+
+func NewGetOpenApiController() *getOpenApiController {
+	return &getOpenApiController{}
+}
+
+func (controller *getOpenApiController) Handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(InfrastructureDocs.OpenApi)
+}
@@ -0,0 +1,61 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type getPushSubscriptionsController struct {
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface
+}
+
+func NewGetPushSubscriptionsController(
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface,
+) *getPushSubscriptionsController {
+	return &getPushSubscriptionsController{
+		pushSubscriptionRepository: pushSubscriptionRepository,
+	}
+}
+
+func (controller *getPushSubscriptionsController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetPushSubscriptionsUsecase(
+		controller.pushSubscriptionRepository,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	subscriptions, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	outputObject := make([]map[string]interface{}, len(subscriptions))
+	for i, subscription := range subscriptions {
+		outputObject[i] = pushSubscriptionOutput(subscription)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(outputObject)
+}
+
+// pushSubscriptionOutput leaves the secret out; it is only returned on creation.
+func pushSubscriptionOutput(subscription DomainEntities.PushSubscriptionEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              subscription.GetId(),
+		"queue_name":      subscription.GetQueueName().GetValue(),
+		"url":             subscription.GetUrl(),
+		"max_concurrency": subscription.GetMaxConcurrency(),
+		"timeout_seconds": subscription.GetTimeoutSeconds(),
+		"created_at":      subscription.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	}
+}
@@ -0,0 +1,79 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type getQueueConfigController struct {
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+}
+
+func NewGetQueueConfigController(
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+) *getQueueConfigController {
+	return &getQueueConfigController{
+		queueConfigRepository: queueConfigRepository,
+	}
+}
+
+func (controller *getQueueConfigController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetQueueConfigUsecase(
+		controller.queueConfigRepository,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	if queueName == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
+		return
+	}
+
+	config, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(queueConfigOutput(*config))
+}
+
+func queueConfigOutput(config DomainEntities.QueueConfigEntity) map[string]interface{} {
+	var deadLetterQueue *string
+	if config.GetDeadLetterQueue() != nil {
+		deadLetterQueueStr := config.GetDeadLetterQueue().GetValue()
+		deadLetterQueue = &deadLetterQueueStr
+	}
+
+	var eventsQueue *string
+	if config.GetEventsQueue() != nil {
+		eventsQueueStr := config.GetEventsQueue().GetValue()
+		eventsQueue = &eventsQueueStr
+	}
+
+	return map[string]interface{}{
+		"queue_name":                   config.GetName().GetValue(),
+		"retention_seconds":            config.GetRetentionSeconds(),
+		"max_length":                   config.GetMaxLength(),
+		"max_receives":                 config.GetMaxReceives(),
+		"dead_letter_queue":            deadLetterQueue,
+		"events_queue":                 eventsQueue,
+		"deduplication_window_seconds": config.GetDeduplicationWindowSeconds(),
+		"default_ttl_seconds":          config.GetDefaultTtlSeconds(),
+		"expiration_policy":            config.GetExpirationPolicy(),
+		"max_message_bytes":            config.GetMaxMessageBytes(),
+		"archive":                      config.IsArchiving(),
+		"archive_retention_seconds":    config.GetArchiveRetentionSeconds(),
+		"paused":                       config.IsPaused(),
+	}
+}
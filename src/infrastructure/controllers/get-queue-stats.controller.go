@@ -0,0 +1,68 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type getQueueStatsController struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewGetQueueStatsController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *getQueueStatsController {
+	return &getQueueStatsController{
+		queueRepository: queueRepository,
+	}
+}
+
+func (controller *getQueueStatsController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetQueueStatsUsecase(
+		controller.queueRepository,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	if queueName == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
+		return
+	}
+
+	stats, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(queueStatsOutput(*stats))
+}
+
+func queueStatsOutput(stats DomainEntities.QueueStatsEntity) map[string]interface{} {
+	var oldestPublishedAt *string
+	if stats.GetOldestPublishedAt() != nil {
+		oldestPublishedAtStr := stats.GetOldestPublishedAt().UTC().Format("2006-01-02 15:04:05.999999")
+		oldestPublishedAt = &oldestPublishedAtStr
+	}
+
+	return map[string]interface{}{
+		"queue_name":          stats.GetName().GetValue(),
+		"messages":            stats.GetMessages(),
+		"visible":             stats.GetVisible(),
+		"in_flight":           stats.GetInFlight(),
+		"delayed":             stats.GetDelayed(),
+		"bytes":               stats.GetBytes(),
+		"oldest_published_at": oldestPublishedAt,
+		"paused":              stats.IsPaused(),
+	}
+}
@@ -0,0 +1,43 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+)
+
+type getQueuesController struct {
+	queueRepository DomainRepositories.QueueRepositoryInterface
+}
+
+func NewGetQueuesController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+) *getQueuesController {
+	return &getQueuesController{
+		queueRepository: queueRepository,
+	}
+}
+
+func (controller *getQueuesController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetQueuesUsecase(
+		controller.queueRepository,
+	)
+
+	stats, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r))
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	outputObject := make([]map[string]interface{}, len(stats))
+	for i, queueStats := range stats {
+		outputObject[i] = queueStatsOutput(queueStats)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(outputObject)
+}
@@ -0,0 +1,67 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+)
+
+type getSchedulesController struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+}
+
+func NewGetSchedulesController(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+) *getSchedulesController {
+	return &getSchedulesController{
+		scheduleRepository: scheduleRepository,
+	}
+}
+
+func (controller *getSchedulesController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetSchedulesUsecase(
+		controller.scheduleRepository,
+	)
+
+	schedules, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r))
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	outputObject := make([]map[string]interface{}, len(schedules))
+	for i, schedule := range schedules {
+		outputObject[i] = scheduleOutput(schedule)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(outputObject)
+}
+
+func scheduleOutput(schedule DomainEntities.ScheduleEntity) map[string]interface{} {
+	var lastRunAt *string
+	if schedule.GetLastRunAt() != nil {
+		lastRunAtStr := schedule.GetLastRunAt().UTC().Format("2006-01-02 15:04:05.999999")
+		lastRunAt = &lastRunAtStr
+	}
+
+	return map[string]interface{}{
+		"id":                schedule.GetId(),
+		"name":              schedule.GetName(),
+		"cron_expression":   schedule.GetCronExpression(),
+		"time_zone":         schedule.GetTimeZone(),
+		"queue_name":        schedule.GetQueueName().GetValue(),
+		"payload":           schedule.GetPayload(),
+		"attributes":        schedule.GetAttributes().GetValues(),
+		"missed_run_policy": schedule.GetMissedRunPolicy(),
+		"paused":            schedule.IsPaused(),
+		"next_run_at":       schedule.GetNextRunAt().UTC().Format("2006-01-02 15:04:05.999999"),
+		"last_run_at":       lastRunAt,
+		"created_at":        schedule.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	}
+}
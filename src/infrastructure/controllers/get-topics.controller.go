@@ -0,0 +1,84 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type getTopicsController struct {
+	topicRepository DomainRepositories.TopicRepositoryInterface
+}
+
+func NewGetTopicsController(
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *getTopicsController {
+	return &getTopicsController{
+		topicRepository: topicRepository,
+	}
+}
+
+// Handle serves both the topic listing and, when the route has a topic
+// variable, that single topic.
+func (controller *getTopicsController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewGetTopicsUsecase(
+		controller.topicRepository,
+	)
+
+	vars := mux.Vars(r)
+	topic := vars["topic"]
+
+	topics, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), topic)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if topic != "" {
+		json.NewEncoder(w).Encode(topicOutput(topics[0]))
+		return
+	}
+
+	outputObject := make([]map[string]interface{}, len(topics))
+	for i, topicEntity := range topics {
+		outputObject[i] = topicOutput(topicEntity)
+	}
+
+	json.NewEncoder(w).Encode(outputObject)
+}
+
+func topicOutput(topic DomainEntities.TopicEntity) map[string]interface{} {
+	subscriptions := make([]map[string]interface{}, len(topic.GetSubscriptions()))
+	for i, subscription := range topic.GetSubscriptions() {
+		subscriptions[i] = topicSubscriptionOutput(subscription)
+	}
+
+	return map[string]interface{}{
+		"topic":         topic.GetName(),
+		"subscriptions": subscriptions,
+	}
+}
+
+func topicSubscriptionOutput(subscription DomainEntities.TopicSubscriptionEntity) map[string]interface{} {
+	filters := make([]string, len(subscription.GetFilters()))
+	for i, filter := range subscription.GetFilters() {
+		filters[i] = filter.String()
+	}
+
+	return map[string]interface{}{
+		"id":         subscription.GetId(),
+		"topic":      subscription.GetTopic(),
+		"queue_name": subscription.GetQueueName().GetValue(),
+		"filters":    filters,
+		"created_at": subscription.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	}
+}
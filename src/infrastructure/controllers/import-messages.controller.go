@@ -0,0 +1,183 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"time"
+)
+
+type importMessagesController struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	batchSize             int
+}
+
+func NewImportMessagesController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	batchSize int,
+) *importMessagesController {
+	return &importMessagesController{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		batchSize:             batchSize,
+	}
+}
+
+// Handle imports the newline-delimited JSON messages of the body, in the
+// format of the export, as they are read.
+func (controller *importMessagesController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewImportMessagesUsecase(
+		controller.queueRepository,
+		controller.queueConfigRepository,
+		controller.batchSize,
+	)
+
+	// Large imports take longer than the server read timeout
+	http.NewResponseController(w).SetReadDeadline(time.Time{})
+
+	tenant := InfrastructureMiddlewares.TenantFromRequest(r)
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+	line := 0
+
+	imported, skipped, err := usecase.Handle(r.Context(), tenant, func() (*DomainEntities.QueueEntity, error) {
+		type requestLine struct {
+			Id             *string           `json:"id"`
+			QueueName      string            `json:"queue_name"`
+			Message        string            `json:"message"`
+			Attributes     map[string]string `json:"attributes"`
+			GroupId        *string           `json:"group_id"`
+			ExpiresAt      *string           `json:"expires_at"`
+			PublishedAt    string            `json:"published_at"`
+			ReservedAt     *string           `json:"reserved_at"`
+			ReservedBy     *string           `json:"reserved_by"`
+			ReservedCount  *int              `json:"reserved_count"`
+			ReservedInfo   *string           `json:"reserved_info"`
+			ReserveExpires *string           `json:"reserve_expires"`
+			Traceparent    *string           `json:"traceparent"`
+		}
+
+		var body requestLine
+		err := decoder.Decode(&body)
+		if err == io.EOF {
+			return nil, err
+		}
+		line++
+		if err != nil {
+			return nil, InfrastructureResponses.NewInvalidBodyError(err)
+		}
+
+		queueNameEntity, err := DomainEntities.NewTenantQueueName(tenant.GetId(), body.QueueName)
+		if err != nil {
+			return nil, err
+		}
+
+		messageEntity, err := DomainEntities.NewQueueMessage(body.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		attributesEntity, err := DomainEntities.NewMessageAttributes(body.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		publishedAt, err := exportedTime("published_at", &body.PublishedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		expiresAt, err := exportedTime("expires_at", body.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		reservedAt, err := exportedTime("reserved_at", body.ReservedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		reserveExpires, err := exportedTime("reserve_expires", body.ReserveExpires)
+		if err != nil {
+			return nil, err
+		}
+
+		// Without a reservation the message is visible once published
+		if reserveExpires == nil {
+			reserveExpires = publishedAt
+		}
+
+		return DomainEntities.NewQueue(
+			body.Id,
+			*queueNameEntity,
+			*messageEntity,
+			*attributesEntity,
+			body.GroupId,
+			expiresAt,
+			*publishedAt,
+			reservedAt,
+			body.ReservedBy,
+			body.ReservedCount,
+			body.ReservedInfo,
+			*reserveExpires,
+			body.Traceparent,
+		)
+	})
+
+	if err != nil {
+		var domainError *DomainEntities.DomainError
+		if errors.As(err, &domainError) {
+			details := map[string]interface{}{}
+			for key, value := range domainError.GetDetails() {
+				details[key] = value
+			}
+			// Errors of a saved batch name their own line
+			if _, ok := details["line"]; !ok {
+				details["line"] = line
+			}
+			details["imported"] = imported
+			details["skipped"] = skipped
+			err = domainError.WithDetails(details)
+		}
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	InfrastructureLogger.FromContext(r.Context()).Info("imported messages",
+		"imported", imported,
+		"skipped", skipped,
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":  "Messages imported successfully",
+		"imported": imported,
+		"skipped":  skipped,
+	})
+}
+
+// exportedTime parses an optional time of an exported message, in the
+// layout of every response.
+func exportedTime(field string, value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	parsed, err := time.ParseInLocation("2006-01-02 15:04:05.999999", *value, time.UTC)
+	if err != nil {
+		return nil, DomainEntities.NewFieldValidationError(field, fmt.Sprintf("%s must look like 2006-01-02 15:04:05.999999", field))
+	}
+
+	return &parsed, nil
+}
@@ -4,35 +4,47 @@ import (
 	"encoding/json"
 	ApplicationUsecases "lean-queue/src/application/usecases"
 	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
 	"net/http"
+	"time"
 )
 
 type publishMessageController struct {
-	queueRepository DomainRepositories.QueueRepositoryInterface
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
 }
 
 func NewPublishMessageController(
 	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
 ) *publishMessageController {
 	return &publishMessageController{
-		queueRepository: queueRepository,
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
 	}
 }
 
 func (controller *publishMessageController) Handle(w http.ResponseWriter, r *http.Request) {
 	usecase := ApplicationUsecases.NewPublishMessageUsecase(
 		controller.queueRepository,
+		controller.queueConfigRepository,
 	)
 
 	type requestBody struct {
-		QueueName string `json:"queue_name"`
-		Message   string `json:"message"`
+		QueueName       string            `json:"queue_name"`
+		Message         string            `json:"message"`
+		Attributes      map[string]string `json:"attributes"`
+		GroupId         *string           `json:"group_id"`
+		ExpiresAt       *time.Time        `json:"expires_at"`
+		TtlSeconds      int               `json:"ttl_seconds"`
+		DeduplicationId string            `json:"deduplication_id"`
 	}
 
 	var body requestBody
 	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		http.Error(w, "Erro ao ler o corpo da requisição: "+err.Error(), http.StatusBadRequest)
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
 		return
 	}
 	defer r.Body.Close()
@@ -40,12 +52,32 @@ func (controller *publishMessageController) Handle(w http.ResponseWriter, r *htt
 	queueName := body.QueueName
 	message := body.Message
 
-	err = usecase.Handle(queueName, message)
+	expiresAt := body.ExpiresAt
+	if expiresAt == nil && body.TtlSeconds > 0 {
+		ttlExpiresAt := time.Now().Add(time.Duration(body.TtlSeconds) * time.Second)
+		expiresAt = &ttlExpiresAt
+	}
+
+	messageId, deduplicated, err := usecase.Handle(
+		r.Context(),
+		InfrastructureMiddlewares.TenantFromRequest(r),
+		queueName,
+		message,
+		body.Attributes,
+		body.GroupId,
+		expiresAt,
+		body.DeduplicationId,
+	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		InfrastructureResponses.WriteError(w, r, err)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Message published successfully"))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":      "Message published successfully",
+		"message_id":   messageId,
+		"deduplicated": deduplicated,
+	})
 }
@@ -0,0 +1,89 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"time"
+)
+
+type publishMessagesController struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+}
+
+func NewPublishMessagesController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+) *publishMessagesController {
+	return &publishMessagesController{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+	}
+}
+
+func (controller *publishMessagesController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewPublishMessagesUsecase(
+		controller.queueRepository,
+		controller.queueConfigRepository,
+	)
+
+	type requestMessage struct {
+		Message    string            `json:"message"`
+		Attributes map[string]string `json:"attributes"`
+		GroupId    *string           `json:"group_id"`
+		ExpiresAt  *time.Time        `json:"expires_at"`
+		TtlSeconds int               `json:"ttl_seconds"`
+	}
+
+	type requestBody struct {
+		QueueName string           `json:"queue_name"`
+		Messages  []requestMessage `json:"messages"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	now := time.Now()
+	messages := make([]ApplicationUsecases.PublishBatchMessage, len(body.Messages))
+	for i, message := range body.Messages {
+		expiresAt := message.ExpiresAt
+		if expiresAt == nil && message.TtlSeconds > 0 {
+			ttlExpiresAt := now.Add(time.Duration(message.TtlSeconds) * time.Second)
+			expiresAt = &ttlExpiresAt
+		}
+
+		messages[i] = ApplicationUsecases.PublishBatchMessage{
+			Message:    message.Message,
+			Attributes: message.Attributes,
+			GroupId:    message.GroupId,
+			ExpiresAt:  expiresAt,
+		}
+	}
+
+	messageIds, err := usecase.Handle(
+		r.Context(),
+		InfrastructureMiddlewares.TenantFromRequest(r),
+		body.QueueName,
+		messages,
+	)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Messages published successfully",
+		"message_ids": messageIds,
+	})
+}
@@ -0,0 +1,93 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type publishTopicMessageController struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	topicRepository       DomainRepositories.TopicRepositoryInterface
+}
+
+func NewPublishTopicMessageController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *publishTopicMessageController {
+	return &publishTopicMessageController{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		topicRepository:       topicRepository,
+	}
+}
+
+func (controller *publishTopicMessageController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewPublishTopicMessageUsecase(
+		controller.queueRepository,
+		controller.queueConfigRepository,
+		controller.topicRepository,
+	)
+
+	vars := mux.Vars(r)
+	topic := vars["topic"]
+
+	type requestBody struct {
+		Message    string            `json:"message"`
+		Attributes map[string]string `json:"attributes"`
+		GroupId    *string           `json:"group_id"`
+		ExpiresAt  *time.Time        `json:"expires_at"`
+		TtlSeconds int               `json:"ttl_seconds"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	expiresAt := body.ExpiresAt
+	if expiresAt == nil && body.TtlSeconds > 0 {
+		ttlExpiresAt := time.Now().Add(time.Duration(body.TtlSeconds) * time.Second)
+		expiresAt = &ttlExpiresAt
+	}
+
+	messages, err := usecase.Handle(
+		r.Context(),
+		InfrastructureMiddlewares.TenantFromRequest(r),
+		topic,
+		body.Message,
+		body.Attributes,
+		body.GroupId,
+		expiresAt,
+	)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	deliveries := make([]map[string]interface{}, len(messages))
+	for i, message := range messages {
+		deliveries[i] = map[string]interface{}{
+			"queue_name": message.GetName().GetValue(),
+			"message_id": message.GetId(),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":    "Message published successfully",
+		"deliveries": deliveries,
+	})
+}
@@ -0,0 +1,102 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type purgeQueueController struct {
+	queueRepository    DomainRepositories.QueueRepositoryInterface
+	confirmationSecret []byte
+	batchSize          int
+}
+
+func NewPurgeQueueController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	confirmationSecret []byte,
+	batchSize int,
+) *purgeQueueController {
+	return &purgeQueueController{
+		queueRepository:    queueRepository,
+		confirmationSecret: confirmationSecret,
+		batchSize:          batchSize,
+	}
+}
+
+// Handle purges in two steps: a request without confirmation_token answers
+// 428 with the token that confirms a purge with the same options.
+func (controller *purgeQueueController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewPurgeQueueUsecase(
+		controller.queueRepository,
+		controller.confirmationSecret,
+		controller.batchSize,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	if queueName == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
+		return
+	}
+
+	type requestBody struct {
+		OnlyVisible       bool   `json:"only_visible"`
+		OlderThanSeconds  int    `json:"older_than_seconds"`
+		ConfirmationToken string `json:"confirmation_token"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	tenant := InfrastructureMiddlewares.TenantFromRequest(r)
+	now := time.Now()
+
+	if body.ConfirmationToken == "" {
+		token, expiresAt := usecase.ConfirmationToken(tenant, queueName, body.OnlyVisible, body.OlderThanSeconds, now)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPreconditionRequired)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"message":            "Repeat the request with confirmation_token to purge the queue",
+			"queue_name":         queueName,
+			"confirmation_token": token,
+			"expires_at":         expiresAt.UTC().Format("2006-01-02 15:04:05.999999"),
+		})
+		return
+	}
+
+	deleted, err := usecase.Handle(r.Context(), tenant, queueName, body.OnlyVisible, body.OlderThanSeconds, body.ConfirmationToken, now)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	InfrastructureLogger.FromContext(r.Context()).Info("purged queue",
+		"queue_name", queueName,
+		"only_visible", body.OnlyVisible,
+		"older_than_seconds", body.OlderThanSeconds,
+		"deleted", deleted,
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":    "Queue purged successfully",
+		"queue_name": queueName,
+		"deleted":    deleted,
+	})
+}
@@ -0,0 +1,82 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type redriveQueueController struct {
+	queueRepository       DomainRepositories.QueueRepositoryInterface
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	batchSize             int
+}
+
+func NewRedriveQueueController(
+	queueRepository DomainRepositories.QueueRepositoryInterface,
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	batchSize int,
+) *redriveQueueController {
+	return &redriveQueueController{
+		queueRepository:       queueRepository,
+		queueConfigRepository: queueConfigRepository,
+		batchSize:             batchSize,
+	}
+}
+
+func (controller *redriveQueueController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewRedriveQueueUsecase(
+		controller.queueRepository,
+		controller.queueConfigRepository,
+		controller.batchSize,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	if queueName == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
+		return
+	}
+
+	type requestBody struct {
+		ToQueue string `json:"to_queue"`
+		Limit   int    `json:"limit"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	target, moved, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName, body.ToQueue, body.Limit, time.Now())
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	InfrastructureLogger.FromContext(r.Context()).Info("redrove queue",
+		"queue_name", queueName,
+		"to_queue", target.GetValue(),
+		"moved", moved,
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":    "Queue redriven successfully",
+		"queue_name": queueName,
+		"to_queue":   target.GetValue(),
+		"moved":      moved,
+	})
+}
@@ -0,0 +1,44 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type removeApiKeyController struct {
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+}
+
+func NewRemoveApiKeyController(
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *removeApiKeyController {
+	return &removeApiKeyController{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+func (controller *removeApiKeyController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewRemoveApiKeyUsecase(
+		controller.apiKeyRepository,
+	)
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	err := usecase.Handle(r.Context(), name)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "API key revoked successfully",
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	ApplicationUsecases "lean-queue/src/application/usecases"
 	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
 	"net/http"
 )
 
@@ -25,20 +27,21 @@ func (controller *removeMessageController) Handle(w http.ResponseWriter, r *http
 	)
 
 	type requestBody struct {
-		MessageId string `json:"message_id"`
+		MessageId   string `json:"message_id"`
+		CompletedBy string `json:"completed_by"`
 	}
 
 	var body requestBody
 	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		http.Error(w, "Erro ao ler o corpo da requisição: "+err.Error(), http.StatusBadRequest)
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
 		return
 	}
 	defer r.Body.Close()
 
-	err = usecase.Handle(body.MessageId)
+	err = usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), body.MessageId, body.CompletedBy)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		InfrastructureResponses.WriteError(w, r, err)
 		return
 	}
 
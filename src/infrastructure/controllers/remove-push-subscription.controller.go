@@ -0,0 +1,46 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type removePushSubscriptionController struct {
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface
+}
+
+func NewRemovePushSubscriptionController(
+	pushSubscriptionRepository DomainRepositories.PushSubscriptionRepositoryInterface,
+) *removePushSubscriptionController {
+	return &removePushSubscriptionController{
+		pushSubscriptionRepository: pushSubscriptionRepository,
+	}
+}
+
+func (controller *removePushSubscriptionController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewRemovePushSubscriptionUsecase(
+		controller.pushSubscriptionRepository,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+	subscriptionId := vars["subscription_id"]
+
+	err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName, subscriptionId)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Push subscription removed successfully",
+	})
+}
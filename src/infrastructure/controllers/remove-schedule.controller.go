@@ -0,0 +1,45 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type removeScheduleController struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+}
+
+func NewRemoveScheduleController(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+) *removeScheduleController {
+	return &removeScheduleController{
+		scheduleRepository: scheduleRepository,
+	}
+}
+
+func (controller *removeScheduleController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewRemoveScheduleUsecase(
+		controller.scheduleRepository,
+	)
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), name)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Schedule removed successfully",
+	})
+}
@@ -0,0 +1,85 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type saveQueueConfigController struct {
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+}
+
+func NewSaveQueueConfigController(
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+) *saveQueueConfigController {
+	return &saveQueueConfigController{
+		queueConfigRepository: queueConfigRepository,
+	}
+}
+
+func (controller *saveQueueConfigController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewSaveQueueConfigUsecase(
+		controller.queueConfigRepository,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	if queueName == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
+		return
+	}
+
+	type requestBody struct {
+		RetentionSeconds           int    `json:"retention_seconds"`
+		MaxLength                  int    `json:"max_length"`
+		MaxReceives                int    `json:"max_receives"`
+		DeadLetterQueue            string `json:"dead_letter_queue"`
+		EventsQueue                string `json:"events_queue"`
+		DeduplicationWindowSeconds int    `json:"deduplication_window_seconds"`
+		DefaultTtlSeconds          int    `json:"default_ttl_seconds"`
+		ExpirationPolicy           string `json:"expiration_policy"`
+		MaxMessageBytes            int    `json:"max_message_bytes"`
+		Archive                    bool   `json:"archive"`
+		ArchiveRetentionSeconds    int    `json:"archive_retention_seconds"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	config, err := usecase.Handle(
+		r.Context(),
+		InfrastructureMiddlewares.TenantFromRequest(r),
+		queueName,
+		body.RetentionSeconds,
+		body.MaxLength,
+		body.MaxReceives,
+		body.DeadLetterQueue,
+		body.EventsQueue,
+		body.DeduplicationWindowSeconds,
+		body.DefaultTtlSeconds,
+		body.ExpirationPolicy,
+		body.MaxMessageBytes,
+		body.Archive,
+		body.ArchiveRetentionSeconds,
+	)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(queueConfigOutput(*config))
+}
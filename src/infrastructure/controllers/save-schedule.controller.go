@@ -0,0 +1,72 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type saveScheduleController struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+}
+
+func NewSaveScheduleController(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+) *saveScheduleController {
+	return &saveScheduleController{
+		scheduleRepository: scheduleRepository,
+	}
+}
+
+func (controller *saveScheduleController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewSaveScheduleUsecase(
+		controller.scheduleRepository,
+	)
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	type requestBody struct {
+		CronExpression  string            `json:"cron_expression"`
+		TimeZone        string            `json:"time_zone"`
+		QueueName       string            `json:"queue_name"`
+		Payload         string            `json:"payload"`
+		Attributes      map[string]string `json:"attributes"`
+		MissedRunPolicy string            `json:"missed_run_policy"`
+		Paused          bool              `json:"paused"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	schedule, err := usecase.Handle(
+		r.Context(),
+		InfrastructureMiddlewares.TenantFromRequest(r),
+		name,
+		body.CronExpression,
+		body.TimeZone,
+		body.QueueName,
+		body.Payload,
+		body.Attributes,
+		body.MissedRunPolicy,
+		body.Paused,
+	)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(scheduleOutput(*schedule))
+}
@@ -0,0 +1,151 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type searchArchiveController struct {
+	queueArchiveRepository DomainRepositories.QueueArchiveRepositoryInterface
+}
+
+func NewSearchArchiveController(
+	queueArchiveRepository DomainRepositories.QueueArchiveRepositoryInterface,
+) *searchArchiveController {
+	return &searchArchiveController{
+		queueArchiveRepository: queueArchiveRepository,
+	}
+}
+
+func (controller *searchArchiveController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewSearchArchiveUsecase(
+		controller.queueArchiveRepository,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	if queueName == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
+		return
+	}
+
+	query := r.URL.Query()
+
+	limit := 100
+	if query.Get("limit") != "" {
+		var err error
+		limit, err = strconv.Atoi(query.Get("limit"))
+		if err != nil {
+			InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("limit", err.Error()))
+			return
+		}
+	}
+
+	var messageId *string
+	if query.Has("message_id") {
+		messageIdStr := query.Get("message_id")
+		messageId = &messageIdStr
+	}
+
+	var completedBy *string
+	if query.Has("completed_by") {
+		completedByStr := query.Get("completed_by")
+		completedBy = &completedByStr
+	}
+
+	completedAfter, err := timeParameter(query.Get("completed_after"))
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("completed_after", err.Error()))
+		return
+	}
+
+	completedBefore, err := timeParameter(query.Get("completed_before"))
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("completed_before", err.Error()))
+		return
+	}
+
+	attributeFilters := make([]DomainEntities.AttributeFilterEntity, 0, len(query["filter"]))
+	for _, expression := range query["filter"] {
+		attributeFilter, err := DomainEntities.ParseAttributeFilter(expression)
+		if err != nil {
+			InfrastructureResponses.WriteError(w, r, err)
+			return
+		}
+		attributeFilters = append(attributeFilters, *attributeFilter)
+	}
+
+	filter, err := DomainEntities.NewArchiveFilter(messageId, completedBy, completedAfter, completedBefore, attributeFilters, query.Get("contains"))
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	archived, nextCursor, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName, *filter, query.Get("cursor"), limit)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	outputObject := make([]map[string]interface{}, len(archived))
+	for i, archivedMessage := range archived {
+		message := archivedMessage.GetMessage()
+
+		var reservedAtStr *string
+		if message.GetReservedAt() != nil {
+			reservedAtStrC := message.GetReservedAt().UTC().Format("2006-01-02 15:04:05.999999")
+			reservedAtStr = &reservedAtStrC
+		}
+
+		var expiresAtStr *string
+		if message.GetExpiresAt() != nil {
+			expiresAtStrC := message.GetExpiresAt().UTC().Format("2006-01-02 15:04:05.999999")
+			expiresAtStr = &expiresAtStrC
+		}
+
+		reservationsOutput := make([]map[string]interface{}, len(archivedMessage.GetReservations()))
+		for j, reservation := range archivedMessage.GetReservations() {
+			reservationsOutput[j] = map[string]interface{}{
+				"reserved_at":     reservation.GetReservedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+				"reserved_by":     reservation.GetReservedBy(),
+				"reserved_info":   reservation.GetReservedInfo(),
+				"reserve_expires": reservation.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+			}
+		}
+
+		outputObject[i] = map[string]interface{}{
+			"id":              message.GetId(),
+			"queue_name":      message.GetName().GetValue(),
+			"message":         message.GetMessage().GetValue(),
+			"attributes":      message.GetAttributes().GetValues(),
+			"group_id":        message.GetGroupId(),
+			"expires_at":      expiresAtStr,
+			"published_at":    message.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+			"reserved_at":     reservedAtStr,
+			"reserved_by":     message.GetReservedBy(),
+			"reserved_count":  message.GetReservedCount(),
+			"reserved_info":   message.GetReservedInfo(),
+			"reserve_expires": message.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+			"traceparent":     message.GetTraceparent(),
+			"completed_at":    archivedMessage.GetCompletedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+			"completed_by":    archivedMessage.GetCompletedBy(),
+			"reservations":    reservationsOutput,
+		}
+	}
+
+	if nextCursor != "" {
+		w.Header().Set("X-Next-Cursor", nextCursor)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(outputObject)
+}
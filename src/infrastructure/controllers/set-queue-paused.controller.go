@@ -0,0 +1,53 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type setQueuePausedController struct {
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface
+	paused                bool
+}
+
+// NewSetQueuePausedController serves the pause route when paused is true
+// and the resume route otherwise.
+func NewSetQueuePausedController(
+	queueConfigRepository DomainRepositories.QueueConfigRepositoryInterface,
+	paused bool,
+) *setQueuePausedController {
+	return &setQueuePausedController{
+		queueConfigRepository: queueConfigRepository,
+		paused:                paused,
+	}
+}
+
+func (controller *setQueuePausedController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewSetQueuePausedUsecase(
+		controller.queueConfigRepository,
+	)
+
+	vars := mux.Vars(r)
+	queueName := vars["queue_name"]
+
+	if queueName == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("queue_name", "missing"))
+		return
+	}
+
+	config, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), queueName, controller.paused)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(queueConfigOutput(*config))
+}
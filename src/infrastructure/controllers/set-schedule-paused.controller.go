@@ -0,0 +1,48 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type setSchedulePausedController struct {
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface
+	paused             bool
+}
+
+// NewSetSchedulePausedController serves the pause route when paused is true
+// and the resume route otherwise.
+func NewSetSchedulePausedController(
+	scheduleRepository DomainRepositories.ScheduleRepositoryInterface,
+	paused bool,
+) *setSchedulePausedController {
+	return &setSchedulePausedController{
+		scheduleRepository: scheduleRepository,
+		paused:             paused,
+	}
+}
+
+func (controller *setSchedulePausedController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewSetSchedulePausedUsecase(
+		controller.scheduleRepository,
+	)
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	schedule, err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), name, controller.paused)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(scheduleOutput(*schedule))
+}
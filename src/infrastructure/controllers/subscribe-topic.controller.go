@@ -0,0 +1,67 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type subscribeTopicController struct {
+	topicRepository DomainRepositories.TopicRepositoryInterface
+}
+
+func NewSubscribeTopicController(
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *subscribeTopicController {
+	return &subscribeTopicController{
+		topicRepository: topicRepository,
+	}
+}
+
+func (controller *subscribeTopicController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewSubscribeTopicUsecase(
+		controller.topicRepository,
+	)
+
+	vars := mux.Vars(r)
+	topic := vars["topic"]
+
+	if topic == "" {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidParameterError("topic", "missing"))
+		return
+	}
+
+	type requestBody struct {
+		QueueName string   `json:"queue_name"`
+		Filters   []string `json:"filters"`
+	}
+
+	var body requestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, InfrastructureResponses.NewInvalidBodyError(err))
+		return
+	}
+	defer r.Body.Close()
+
+	subscription, err := usecase.Handle(
+		r.Context(),
+		InfrastructureMiddlewares.TenantFromRequest(r),
+		topic,
+		body.QueueName,
+		body.Filters,
+	)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(topicSubscriptionOutput(*subscription))
+}
@@ -0,0 +1,46 @@
+package InfrastructureControllers
+
+import (
+	"encoding/json"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type unsubscribeTopicController struct {
+	topicRepository DomainRepositories.TopicRepositoryInterface
+}
+
+func NewUnsubscribeTopicController(
+	topicRepository DomainRepositories.TopicRepositoryInterface,
+) *unsubscribeTopicController {
+	return &unsubscribeTopicController{
+		topicRepository: topicRepository,
+	}
+}
+
+func (controller *unsubscribeTopicController) Handle(w http.ResponseWriter, r *http.Request) {
+	usecase := ApplicationUsecases.NewUnsubscribeTopicUsecase(
+		controller.topicRepository,
+	)
+
+	vars := mux.Vars(r)
+	topic := vars["topic"]
+	subscriptionId := vars["subscription_id"]
+
+	err := usecase.Handle(r.Context(), InfrastructureMiddlewares.TenantFromRequest(r), topic, subscriptionId)
+	if err != nil {
+		InfrastructureResponses.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Subscription removed successfully",
+	})
+}
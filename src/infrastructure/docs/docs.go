@@ -0,0 +1,62 @@
+package InfrastructureDocs
+
+import (
+	_ "embed"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// OpenApi is the OpenAPI 3 document of the /v1 routes.
+//
+//go:embed openapi.json
+var OpenApi []byte
+
+// Page renders OpenApi in the browser, without external assets.
+//
+//go:embed docs.html
+var Page []byte
+
+var routeVariableRegexp = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// UndocumentedRoutes returns "METHOD /path" of every /v1 route of router
+// missing from OpenApi, so new routes are not forgotten in the docs.
+func UndocumentedRoutes(router *mux.Router) ([]string, error) {
+	var document struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(OpenApi, &document); err != nil {
+		return nil, err
+	}
+
+	var undocumented []string
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(template, "/v1/") {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		path := routeVariableRegexp.ReplaceAllString(template, "{$1}")
+		for _, method := range methods {
+			if _, ok := document.Paths[path][strings.ToLower(method)]; !ok {
+				undocumented = append(undocumented, method+" "+path)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(undocumented)
+	return undocumented, nil
+}
@@ -0,0 +1,35 @@
+package InfrastructureDocs_test
+
+import (
+	InfrastructureDocs "lean-queue/src/infrastructure/docs"
+	InfrastructureRoutes "lean-queue/src/infrastructure/routes"
+	"net/http"
+	"testing"
+)
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	router := InfrastructureRoutes.NewRouter(nil, InfrastructureRoutes.Config{})
+
+	undocumented, err := InfrastructureDocs.UndocumentedRoutes(router)
+	if err != nil {
+		t.Fatalf("UndocumentedRoutes failed: %v", err)
+	}
+
+	for _, route := range undocumented {
+		t.Errorf("route %s missing from openapi.json", route)
+	}
+}
+
+func TestUndocumentedRoutesFindsNewRoutes(t *testing.T) {
+	router := InfrastructureRoutes.NewRouter(nil, InfrastructureRoutes.Config{})
+	router.HandleFunc("/v1/queues/{queue_name:[a-z]+}/undocumented", func(w http.ResponseWriter, r *http.Request) {}).Methods("PATCH")
+
+	undocumented, err := InfrastructureDocs.UndocumentedRoutes(router)
+	if err != nil {
+		t.Fatalf("UndocumentedRoutes failed: %v", err)
+	}
+
+	if len(undocumented) != 1 || undocumented[0] != "PATCH /v1/queues/{queue_name}/undocumented" {
+		t.Errorf("UndocumentedRoutes = %q, want the new route only", undocumented)
+	}
+}
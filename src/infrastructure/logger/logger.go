@@ -0,0 +1,110 @@
+package InfrastructureLogger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type contextKey string
+
+const loggerContextKey contextKey = "logger"
+
+// PayloadKeys are attribute keys holding message bodies, redacted unless
+// payload logging is enabled.
+var PayloadKeys = map[string]bool{
+	"message": true,
+	"payload": true,
+	"body":    true,
+}
+
+type Config struct {
+	// Level is one of debug, info, warn or error
+	Level string
+	// Format is json or text
+	Format string
+	// Outputs are stdout, stderr or file paths, appended to
+	Outputs []string
+	// LogPayloads disables the redaction of PayloadKeys
+	LogPayloads bool `mapstructure:"log_payloads"`
+}
+
+// New builds the logger described by config. The returned function flushes
+// and closes the file outputs.
+func New(config Config) (*slog.Logger, func(), error) {
+	var level slog.Level
+	if config.Level != "" {
+		if err := level.UnmarshalText([]byte(config.Level)); err != nil {
+			return nil, nil, fmt.Errorf("invalid log level %q: %w", config.Level, err)
+		}
+	}
+
+	if len(config.Outputs) == 0 {
+		config.Outputs = []string{"stdout"}
+	}
+
+	var writers []io.Writer
+	var files []*os.File
+	closeFiles := func() {
+		for _, file := range files {
+			file.Sync()
+			file.Close()
+		}
+	}
+
+	for _, output := range config.Outputs {
+		switch output {
+		case "stdout":
+			writers = append(writers, os.Stdout)
+		case "stderr":
+			writers = append(writers, os.Stderr)
+		default:
+			file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				closeFiles()
+				return nil, nil, err
+			}
+			files = append(files, file)
+			writers = append(writers, file)
+		}
+	}
+
+	options := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if !config.LogPayloads && PayloadKeys[attr.Key] {
+				return slog.String(attr.Key, "[REDACTED]")
+			}
+			return attr
+		},
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(config.Format) {
+	case "", "json":
+		handler = slog.NewJSONHandler(io.MultiWriter(writers...), options)
+	case "text":
+		handler = slog.NewTextHandler(io.MultiWriter(writers...), options)
+	default:
+		closeFiles()
+		return nil, nil, fmt.Errorf("invalid log format %q", config.Format)
+	}
+
+	return slog.New(handler), closeFiles, nil
+}
+
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, logger)
+}
+
+// FromContext returns the request scoped logger, or the default one.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
@@ -0,0 +1,35 @@
+package InfrastructureMiddlewares
+
+import (
+	DomainEntities "lean-queue/src/domain/entities"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+)
+
+type adminMiddleware struct {
+	adminApiKeys map[string]bool
+}
+
+// NewAdminMiddleware only lets through requests authenticated with one of
+// adminApiKeys or with a stored admin key. It runs after the API key auth
+// middleware.
+func NewAdminMiddleware(
+	adminApiKeys map[string]bool,
+) *adminMiddleware {
+	return &adminMiddleware{
+		adminApiKeys: adminApiKeys,
+	}
+}
+
+func (middleware *adminMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("ApiAuthorization")
+
+		if (token == "" || !middleware.adminApiKeys[token]) && !isAdminRequest(r) {
+			InfrastructureResponses.WriteError(w, r, DomainEntities.NewDomainError(DomainEntities.ErrorKindForbidden, "permission_denied", "permission denied"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,160 @@
+package InfrastructureMiddlewares
+
+import (
+	"context"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type contextKey string
+
+const (
+	tenantContextKey     contextKey = "tenant"
+	adminContextKey      contextKey = "admin"
+	apiKeyNameContextKey contextKey = "api_key_name"
+)
+
+// StoredApiKeyCacheTTL is how long a stored API key is accepted without
+// reading it again, so also how long a revoked key keeps working.
+const StoredApiKeyCacheTTL = 10 * time.Second
+
+type storedApiKey struct {
+	name      string
+	tenant    DomainEntities.TenantEntity
+	admin     bool
+	expiresAt time.Time
+}
+
+type apiKeyAuthMiddleware struct {
+	tenantsByApiKey  map[string]DomainEntities.TenantEntity
+	tenantsById      map[string]DomainEntities.TenantEntity
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface
+	mutex            sync.Mutex
+	storedApiKeys    map[string]storedApiKey
+}
+
+// NewApiKeyAuthMiddleware receives every API key of the config file mapped
+// to the tenant whose namespace the key operates on. Other keys are looked
+// up in apiKeyRepository, their tenant taking its quotas from tenantsById.
+func NewApiKeyAuthMiddleware(
+	tenantsByApiKey map[string]DomainEntities.TenantEntity,
+	tenantsById map[string]DomainEntities.TenantEntity,
+	apiKeyRepository DomainRepositories.ApiKeyRepositoryInterface,
+) *apiKeyAuthMiddleware {
+	return &apiKeyAuthMiddleware{
+		tenantsByApiKey:  tenantsByApiKey,
+		tenantsById:      tenantsById,
+		apiKeyRepository: apiKeyRepository,
+		storedApiKeys:    map[string]storedApiKey{},
+	}
+}
+
+func (middleware *apiKeyAuthMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("ApiAuthorization")
+
+		ctx := r.Context()
+		if tenant, ok := middleware.tenantsByApiKey[token]; token != "" && ok {
+			ctx = context.WithValue(ctx, tenantContextKey, tenant)
+		} else {
+			apiKey, err := middleware.storedApiKey(r.Context(), token)
+			if err != nil {
+				InfrastructureResponses.WriteError(w, r, err)
+				return
+			}
+
+			if apiKey == nil {
+				InfrastructureResponses.WriteError(w, r, DomainEntities.NewDomainError(DomainEntities.ErrorKindUnauthorized, "invalid_api_key", "invalid api key"))
+				return
+			}
+
+			ctx = context.WithValue(ctx, tenantContextKey, apiKey.tenant)
+			ctx = context.WithValue(ctx, adminContextKey, apiKey.admin)
+			ctx = context.WithValue(ctx, apiKeyNameContextKey, apiKey.name)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// storedApiKey returns the stored key matching token, or nil when there is
+// none. Accepted keys are cached for StoredApiKeyCacheTTL.
+func (middleware *apiKeyAuthMiddleware) storedApiKey(ctx context.Context, token string) (*storedApiKey, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	middleware.mutex.Lock()
+	cached, ok := middleware.storedApiKeys[token]
+	middleware.mutex.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return &cached, nil
+	}
+
+	apiKey, err := ApplicationUsecases.NewAuthenticateApiKeyUsecase(middleware.apiKeyRepository).Handle(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey == nil {
+		if ok {
+			middleware.mutex.Lock()
+			delete(middleware.storedApiKeys, token)
+			middleware.mutex.Unlock()
+		}
+		return nil, nil
+	}
+
+	tenant, ok := middleware.tenantsById[apiKey.GetTenant()]
+	if !ok {
+		unconfiguredTenant, err := DomainEntities.NewTenant(apiKey.GetTenant(), 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		tenant = *unconfiguredTenant
+	}
+
+	cached = storedApiKey{
+		name:      apiKey.GetName(),
+		tenant:    tenant,
+		admin:     apiKey.IsAdmin(),
+		expiresAt: now.Add(StoredApiKeyCacheTTL),
+	}
+
+	middleware.mutex.Lock()
+	middleware.storedApiKeys[token] = cached
+	middleware.mutex.Unlock()
+
+	return &cached, nil
+}
+
+// TenantFromRequest returns the tenant authenticated for the request, or the
+// default tenant when the request did not go through the auth middleware.
+func TenantFromRequest(r *http.Request) DomainEntities.TenantEntity {
+	if tenant, ok := r.Context().Value(tenantContextKey).(DomainEntities.TenantEntity); ok {
+		return tenant
+	}
+
+	return DomainEntities.TenantEntity{}
+}
+
+// apiKeyNameFromRequest returns the name of the stored API key authenticating
+// the request, or "" for keys of the config file.
+func apiKeyNameFromRequest(r *http.Request) string {
+	name, _ := r.Context().Value(apiKeyNameContextKey).(string)
+	return name
+}
+
+// isAdminRequest reports whether the request was authenticated with a stored
+// admin key.
+func isAdminRequest(r *http.Request) bool {
+	admin, _ := r.Context().Value(adminContextKey).(bool)
+	return admin
+}
@@ -0,0 +1,229 @@
+package InfrastructureMiddlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	DomainEntities "lean-queue/src/domain/entities"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// RateLimitRule describes a token bucket refilled at Rate tokens per second
+// holding at most Burst tokens. A zero Rate disables the limit.
+type RateLimitRule struct {
+	Rate  float64
+	Burst int
+}
+
+type RateLimitConfig struct {
+	ApiKey RateLimitRule
+	Queue  RateLimitRule
+	// ApiKeys overrides are keyed by API key token, StoredApiKeys overrides
+	// by the name of a stored API key
+	ApiKeys       map[string]RateLimitRule
+	StoredApiKeys map[string]RateLimitRule
+	// Queues overrides are keyed by queue name, prefixed by "tenant/" for the
+	// queues of a tenant
+	Queues map[string]RateLimitRule
+}
+
+type tokenBucket struct {
+	rule     RateLimitRule
+	tokens   float64
+	lastSeen time.Time
+}
+
+// refill adds the tokens earned since last seen, returning how long to wait
+// for a whole token when the bucket is empty.
+func (bucket *tokenBucket) refill(now time.Time) time.Duration {
+	elapsed := now.Sub(bucket.lastSeen).Seconds()
+	bucket.tokens = math.Min(float64(bucket.rule.Burst), bucket.tokens+elapsed*bucket.rule.Rate)
+	bucket.lastSeen = now
+
+	if bucket.tokens >= 1 {
+		return 0
+	}
+
+	wait := (1 - bucket.tokens) / bucket.rule.Rate
+	return time.Duration(wait * float64(time.Second))
+}
+
+func (bucket *tokenBucket) isFull(now time.Time) bool {
+	elapsed := now.Sub(bucket.lastSeen).Seconds()
+	return bucket.tokens+elapsed*bucket.rule.Rate >= float64(bucket.rule.Burst)
+}
+
+type rateLimitMiddleware struct {
+	config    RateLimitConfig
+	queueName func(r *http.Request) string
+	mutex     sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// NewRateLimitMiddleware limits an operation per API key and per queue.
+func NewRateLimitMiddleware(
+	config RateLimitConfig,
+	queueName func(r *http.Request) string,
+) *rateLimitMiddleware {
+	return &rateLimitMiddleware{
+		config:    config,
+		queueName: queueName,
+		buckets:   map[string]*tokenBucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+func (middleware *rateLimitMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queueName := tenantQueueName(r, middleware.queueName(r))
+		queueRule, ok := middleware.config.Queues[queueName]
+		if !ok {
+			queueRule = middleware.config.Queue
+		}
+
+		middleware.limit(w, r, next, "queue:"+queueName, queueRule)
+	})
+}
+
+// HandleTopic limits publishes to the topic of the path per API key, sharing
+// the buckets of the keys with Handle, and per topic with the Queue rule.
+func (middleware *rateLimitMiddleware) HandleTopic(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		topic := tenantQueueName(r, mux.Vars(r)["topic"])
+
+		middleware.limit(w, r, next, "topic:"+topic, middleware.config.Queue)
+	})
+}
+
+// limit serves the request when both the bucket of its API key and the one
+// of scope have a token left.
+func (middleware *rateLimitMiddleware) limit(w http.ResponseWriter, r *http.Request, next http.Handler, scope string, scopeRule RateLimitRule) {
+	apiKey := r.Header.Get("ApiAuthorization")
+	apiKeyRule, ok := middleware.config.ApiKeys[apiKey]
+	if !ok {
+		apiKeyRule, ok = middleware.config.StoredApiKeys[apiKeyNameFromRequest(r)]
+	}
+	if !ok {
+		apiKeyRule = middleware.config.ApiKey
+	}
+
+	allowed, wait := middleware.allow(
+		map[string]RateLimitRule{
+			"key:" + apiKey: apiKeyRule,
+			scope:           scopeRule,
+		},
+	)
+
+	if !allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		InfrastructureResponses.WriteError(w, r, DomainEntities.NewDomainError(DomainEntities.ErrorKindTooManyRequests, "rate_limit_exceeded", "rate limit exceeded"))
+		return
+	}
+
+	next.ServeHTTP(w, r)
+}
+
+// tenantQueueName qualifies name with the tenant of the request, except for
+// the default tenant. Queue and topic names never contain a slash.
+func tenantQueueName(r *http.Request, name string) string {
+	tenant := TenantFromRequest(r).GetId()
+	if tenant == "" {
+		return name
+	}
+
+	return tenant + "/" + name
+}
+
+func (middleware *rateLimitMiddleware) allow(rules map[string]RateLimitRule) (bool, time.Duration) {
+	middleware.mutex.Lock()
+	defer middleware.mutex.Unlock()
+
+	now := time.Now()
+	middleware.sweep(now)
+
+	var buckets []*tokenBucket
+	var wait time.Duration
+
+	for key, rule := range rules {
+		if rule.Rate <= 0 {
+			continue
+		}
+		if rule.Burst < 1 {
+			rule.Burst = 1
+		}
+
+		bucket, ok := middleware.buckets[key]
+		if !ok || bucket.rule != rule {
+			bucket = &tokenBucket{rule: rule, tokens: float64(rule.Burst), lastSeen: now}
+			middleware.buckets[key] = bucket
+		}
+
+		if bucketWait := bucket.refill(now); bucketWait > wait {
+			wait = bucketWait
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	// A request rejected by one bucket must not spend tokens of the others
+	if wait > 0 {
+		return false, wait
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+
+	return true, 0
+}
+
+// sweep drops refilled buckets so idle keys and queues do not pile up in memory.
+func (middleware *rateLimitMiddleware) sweep(now time.Time) {
+	if now.Sub(middleware.lastSweep) < time.Minute {
+		return
+	}
+	middleware.lastSweep = now
+
+	for key, bucket := range middleware.buckets {
+		if bucket.isFull(now) {
+			delete(middleware.buckets, key)
+		}
+	}
+}
+
+func QueueNameFromQuery(r *http.Request) string {
+	return r.URL.Query().Get("queue_name")
+}
+
+// QueueNameFromBody peeks at the queue_name of a JSON body, leaving the body
+// readable by the next handler. At most MaxRequestBodyBytes are buffered: a
+// larger body is passed on whole without a queue name, for the handler to reject.
+func QueueNameFromBody(r *http.Request) string {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestBodyBytes+1))
+	if err != nil || len(body) > MaxRequestBodyBytes {
+		r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		return ""
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		QueueName string `json:"queue_name"`
+	}
+	json.Unmarshal(body, &payload)
+
+	return payload.QueueName
+}
+
+// readCloser reads from Reader and closes Closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,68 @@
+package InfrastructureMiddlewares
+
+import (
+	"context"
+	DomainEntities "lean-queue/src/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// allowedRequests sends requests to queueName, authenticated as tenant with
+// the stored API key apiKeyName when not empty, and counts the allowed ones.
+func allowedRequests(t *testing.T, middleware *rateLimitMiddleware, tenantId string, apiKeyName string, queueName string, requests int) int {
+	t.Helper()
+
+	tenant, err := DomainEntities.NewTenant(tenantId, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowed := 0
+	handler := middleware.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed++
+	}))
+
+	for i := 0; i < requests; i++ {
+		request := httptest.NewRequest(http.MethodGet, "/?queue_name="+queueName, nil)
+		request.Header.Set("ApiAuthorization", tenantId+"-"+apiKeyName)
+		ctx := context.WithValue(request.Context(), tenantContextKey, *tenant)
+		if apiKeyName != "" {
+			ctx = context.WithValue(ctx, apiKeyNameContextKey, apiKeyName)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), request.WithContext(ctx))
+	}
+
+	return allowed
+}
+
+func TestRateLimitOverridesStoredApiKeysByName(t *testing.T) {
+	middleware := NewRateLimitMiddleware(RateLimitConfig{
+		ApiKey:        RateLimitRule{Rate: 0.001, Burst: 1},
+		StoredApiKeys: map[string]RateLimitRule{"bulk": {Rate: 0.001, Burst: 3}},
+	}, QueueNameFromQuery)
+
+	if allowed := allowedRequests(t, middleware, "acme", "bulk", "orders", 5); allowed != 3 {
+		t.Errorf("stored key bulk allowed %d requests, want its override of 3", allowed)
+	}
+	if allowed := allowedRequests(t, middleware, "acme", "other", "orders", 5); allowed != 1 {
+		t.Errorf("stored key other allowed %d requests, want the default of 1", allowed)
+	}
+}
+
+func TestRateLimitOverridesQueuesOfTheirTenant(t *testing.T) {
+	middleware := NewRateLimitMiddleware(RateLimitConfig{
+		Queue:  RateLimitRule{Rate: 0.001, Burst: 1},
+		Queues: map[string]RateLimitRule{"acme/orders": {Rate: 0.001, Burst: 3}},
+	}, QueueNameFromQuery)
+
+	if allowed := allowedRequests(t, middleware, "acme", "", "orders", 5); allowed != 3 {
+		t.Errorf("orders of acme allowed %d requests, want its override of 3", allowed)
+	}
+	if allowed := allowedRequests(t, middleware, "globex", "", "orders", 5); allowed != 1 {
+		t.Errorf("orders of globex allowed %d requests, want the default of 1", allowed)
+	}
+	if allowed := allowedRequests(t, middleware, "", "", "orders", 5); allowed != 1 {
+		t.Errorf("orders of the default tenant allowed %d requests, want the default of 1", allowed)
+	}
+}
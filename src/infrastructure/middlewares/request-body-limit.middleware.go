@@ -0,0 +1,73 @@
+package InfrastructureMiddlewares
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	DomainEntities "lean-queue/src/domain/entities"
+	"mime"
+	"net/http"
+)
+
+// MaxRequestBodyBytes bounds the JSON bodies read by the API: twice the
+// largest message, leaving room for JSON escaping, plus the rest of the envelope.
+const MaxRequestBodyBytes = 2*DomainEntities.MaxQueueMessageBytes + 64<<10
+
+type requestBodyLimitMiddleware struct {
+	maxBytes int64
+}
+
+// NewRequestBodyLimitMiddleware fails reading request bodies past maxBytes.
+// Newline-delimited JSON bodies are streams of any length, so only each of
+// their lines is bounded instead.
+func NewRequestBodyLimitMiddleware(maxBytes int64) *requestBodyLimitMiddleware {
+	return &requestBodyLimitMiddleware{
+		maxBytes: maxBytes,
+	}
+}
+
+func (middleware *requestBodyLimitMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == "application/x-ndjson" {
+			r.Body = &lineLimitedReader{ReadCloser: r.Body, maxBytes: middleware.maxBytes}
+		} else {
+			r.Body = http.MaxBytesReader(w, r.Body, middleware.maxBytes)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// lineLimitedReader fails once a line grows past maxBytes.
+type lineLimitedReader struct {
+	io.ReadCloser
+	maxBytes  int64
+	lineBytes int64
+}
+
+func (reader *lineLimitedReader) Read(p []byte) (int, error) {
+	n, err := reader.ReadCloser.Read(p)
+
+	read := p[:n]
+	for len(read) > 0 {
+		line := read
+		index := bytes.IndexByte(read, '\n')
+		if index >= 0 {
+			line = read[:index]
+		}
+
+		reader.lineBytes += int64(len(line))
+		if reader.lineBytes > reader.maxBytes {
+			return n, fmt.Errorf("line longer than %d bytes", reader.maxBytes)
+		}
+
+		if index < 0 {
+			break
+		}
+		reader.lineBytes = 0
+		read = read[index+1:]
+	}
+
+	return n, err
+}
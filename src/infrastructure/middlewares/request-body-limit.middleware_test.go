@@ -0,0 +1,42 @@
+package InfrastructureMiddlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func readBody(t *testing.T, contentType string, body string) error {
+	t.Helper()
+
+	var readErr error
+	handler := NewRequestBodyLimitMiddleware(10).Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	return readErr
+}
+
+func TestRequestBodyLimit(t *testing.T) {
+	if err := readBody(t, "application/json", `{"a":"b"}`); err != nil {
+		t.Errorf("body within the limit failed: %v", err)
+	}
+
+	if err := readBody(t, "application/json", `{"a":"bcdefgh"}`); err == nil {
+		t.Error("body over the limit was read")
+	}
+
+	if err := readBody(t, "application/x-ndjson", "{\"a\":1}\n{\"b\":2}\n{\"c\":3}\n"); err != nil {
+		t.Errorf("stream of short lines failed: %v", err)
+	}
+
+	if err := readBody(t, "application/x-ndjson", "{\"a\":1}\n{\"b\":\"cdefgh\"}\n"); err == nil {
+		t.Error("stream with a line over the limit was read")
+	}
+}
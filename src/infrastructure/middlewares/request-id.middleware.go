@@ -0,0 +1,61 @@
+package InfrastructureMiddlewares
+
+import (
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const RequestIdHeader = "X-Request-Id"
+
+type requestIdMiddleware struct{}
+
+// NewRequestIdMiddleware propagates the caller X-Request-Id, or generates one,
+// and logs every request with it.
+func NewRequestIdMiddleware() *requestIdMiddleware {
+	return &requestIdMiddleware{}
+}
+
+func (middleware *requestIdMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(RequestIdHeader)
+		if requestId == "" || len(requestId) > 128 {
+			requestId = uuid.New().String()
+		}
+		w.Header().Set(RequestIdHeader, requestId)
+
+		logger := slog.Default().With("request_id", requestId)
+		ctx := InfrastructureLogger.WithLogger(r.Context(), logger)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		startedAt := time.Now()
+
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		logger.Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.status,
+			"duration_ms", time.Since(startedAt).Milliseconds(),
+		)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,117 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
+)
+
+// QueueRepository also implements DomainRepositories.ApiKeyRepositoryInterface.
+
+func (repository *QueueRepository) ListApiKeys(ctx context.Context) ([]DomainEntities.ApiKeyEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.ListApiKeys")
+	defer span.End()
+
+	rows, err := repository.dbPool.QueryContext(ctx, `
+		SELECT name, tenant, key_hash, admin, created_at
+		FROM api_keys
+		ORDER BY name ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := []DomainEntities.ApiKeyEntity{}
+	for rows.Next() {
+		apiKey, err := scanApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		apiKeys = append(apiKeys, *apiKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (repository *QueueRepository) GetApiKeyByHash(ctx context.Context, keyHash string) (*DomainEntities.ApiKeyEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetApiKeyByHash")
+	defer span.End()
+
+	row := repository.dbPool.QueryRowContext(ctx, `
+		SELECT name, tenant, key_hash, admin, created_at
+		FROM api_keys
+		WHERE key_hash = ?
+	`, keyHash)
+
+	apiKey, err := scanApiKey(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return apiKey, err
+}
+
+func (repository *QueueRepository) SaveApiKey(ctx context.Context, apiKey DomainEntities.ApiKeyEntity) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SaveApiKey")
+	defer span.End()
+
+	_, err := repository.dbPool.ExecContext(ctx, `
+		INSERT INTO api_keys (name, tenant, key_hash, admin, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`,
+		apiKey.GetName(),
+		apiKey.GetTenant(),
+		apiKey.GetKeyHash(),
+		apiKey.IsAdmin(),
+		apiKey.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	)
+
+	return err
+}
+
+func (repository *QueueRepository) RemoveApiKey(ctx context.Context, name string) (bool, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemoveApiKey")
+	defer span.End()
+
+	result, err := repository.dbPool.ExecContext(ctx, `
+		DELETE FROM api_keys
+		WHERE name = ?
+	`, name)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, nil
+}
+
+func scanApiKey(row rowScanner) (*DomainEntities.ApiKeyEntity, error) {
+	var name string
+	var tenant string
+	var keyHash string
+	var admin bool
+	var createdAtStr string
+
+	err := row.Scan(&name, &tenant, &keyHash, &admin, &createdAtStr)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := parseDateTime(createdAtStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created_at date: %w", err)
+	}
+
+	return DomainEntities.NewApiKey(name, tenant, keyHash, admin, createdAt)
+}
@@ -0,0 +1,47 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+// DatabaseLock is a MySQL named lock held by a dedicated connection. It is
+// released when Release is called or when the connection dies.
+type DatabaseLock struct {
+	name       string
+	connection *sql.Conn
+}
+
+// TryLock acquires the named lock without waiting. It returns nil when another
+// instance holds it.
+func (repository *QueueRepository) TryLock(ctx context.Context, name string) (*DatabaseLock, error) {
+	connection, err := repository.dbPool.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var acquired sql.NullInt64
+	err = connection.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&acquired)
+	if err != nil || acquired.Int64 != 1 {
+		connection.Close()
+		return nil, err
+	}
+
+	return &DatabaseLock{name: name, connection: connection}, nil
+}
+
+// IsHeld checks the lock still belongs to this connection.
+func (lock *DatabaseLock) IsHeld(ctx context.Context) bool {
+	var held sql.NullInt64
+	err := lock.connection.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?) = CONNECTION_ID()", lock.name).Scan(&held)
+
+	return err == nil && held.Int64 == 1
+}
+
+func (lock *DatabaseLock) Release() {
+	if _, err := lock.connection.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lock.name); err != nil {
+		slog.Warn("failed to release lock", "lock", lock.name, "error", err)
+	}
+	lock.connection.Close()
+}
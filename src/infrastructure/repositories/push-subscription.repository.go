@@ -0,0 +1,126 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
+)
+
+// QueueRepository also implements DomainRepositories.PushSubscriptionRepositoryInterface.
+
+func (repository *QueueRepository) ListPushSubscriptions(ctx context.Context) ([]DomainEntities.PushSubscriptionEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.ListPushSubscriptions")
+	defer span.End()
+
+	return repository.queryPushSubscriptions(ctx, `
+		SELECT id, tenant, queue_name, url, secret, max_concurrency, timeout_seconds, created_at
+		FROM push_subscriptions
+		ORDER BY created_at ASC
+	`)
+}
+
+func (repository *QueueRepository) GetPushSubscriptions(ctx context.Context, queueName DomainEntities.QueueNameEntity) ([]DomainEntities.PushSubscriptionEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetPushSubscriptions")
+	defer span.End()
+
+	return repository.queryPushSubscriptions(ctx, `
+		SELECT id, tenant, queue_name, url, secret, max_concurrency, timeout_seconds, created_at
+		FROM push_subscriptions
+		WHERE tenant = ?
+		  AND queue_name = ?
+		ORDER BY created_at ASC
+	`, queueName.GetTenant(), queueName.GetValue())
+}
+
+func (repository *QueueRepository) SavePushSubscription(ctx context.Context, subscription DomainEntities.PushSubscriptionEntity) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SavePushSubscription")
+	defer span.End()
+
+	_, err := repository.dbPool.ExecContext(ctx, `
+		INSERT INTO push_subscriptions (id, tenant, queue_name, url, secret, max_concurrency, timeout_seconds, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			url = VALUES(url),
+			secret = VALUES(secret),
+			max_concurrency = VALUES(max_concurrency),
+			timeout_seconds = VALUES(timeout_seconds)
+	`,
+		subscription.GetId(),
+		subscription.GetQueueName().GetTenant(),
+		subscription.GetQueueName().GetValue(),
+		subscription.GetUrl(),
+		subscription.GetSecret(),
+		subscription.GetMaxConcurrency(),
+		subscription.GetTimeoutSeconds(),
+		subscription.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	)
+
+	return err
+}
+
+func (repository *QueueRepository) RemovePushSubscription(ctx context.Context, queueName DomainEntities.QueueNameEntity, id string) (bool, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemovePushSubscription")
+	defer span.End()
+
+	result, err := repository.dbPool.ExecContext(ctx, `
+		DELETE FROM push_subscriptions
+		WHERE tenant = ?
+		  AND queue_name = ?
+		  AND id = ?
+	`, queueName.GetTenant(), queueName.GetValue(), id)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, nil
+}
+
+func (repository *QueueRepository) queryPushSubscriptions(ctx context.Context, query string, args ...interface{}) ([]DomainEntities.PushSubscriptionEntity, error) {
+	rows, err := repository.dbPool.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []DomainEntities.PushSubscriptionEntity{}
+	for rows.Next() {
+		var id string
+		var tenant string
+		var queueName string
+		var url string
+		var secret string
+		var maxConcurrency int
+		var timeoutSeconds int
+		var createdAtStr string
+
+		err = rows.Scan(&id, &tenant, &queueName, &url, &secret, &maxConcurrency, &timeoutSeconds, &createdAtStr)
+		if err != nil {
+			return nil, err
+		}
+
+		createdAt, err := parseDateTime(createdAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse created_at date: %w", err)
+		}
+
+		queueNameEntity := DomainEntities.RestoreTenantQueueName(tenant, queueName)
+
+		subscription, err := DomainEntities.NewPushSubscription(&id, queueNameEntity, url, secret, maxConcurrency, timeoutSeconds, createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
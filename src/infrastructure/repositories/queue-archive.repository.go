@@ -0,0 +1,392 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
+	"strings"
+	"time"
+)
+
+// The archive lives next to the messages, so QueueRepository also
+// implements DomainRepositories.QueueArchiveRepositoryInterface.
+
+type archivedReservation struct {
+	ReservedAt     string  `json:"reserved_at"`
+	ReservedBy     *string `json:"reserved_by"`
+	ReservedInfo   *string `json:"reserved_info"`
+	ReserveExpires string  `json:"reserve_expires"`
+}
+
+// CompleteById removes a message, moving it first into the archive with its
+// reservation history when its queue archives. completedBy defaults to the
+// last consumer to reserve the message.
+func (repository *QueueRepository) CompleteById(ctx context.Context, tenant string, id string, completedBy *string, completedAt time.Time) error {
+	ctx, span := startSpan(ctx, "QueueRepository.CompleteById")
+	defer span.End()
+
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var archive bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(c.archive, 0)
+		FROM queue_messages m
+		LEFT JOIN queue_configs c ON c.tenant = m.tenant AND c.name = m.name
+		WHERE m.tenant = ?
+		  AND m.id = ?
+		FOR UPDATE OF m
+	`, tenant, id).Scan(&archive)
+	if err == sql.ErrNoRows {
+		return tx.Commit()
+	}
+	if err != nil {
+		return err
+	}
+
+	if archive {
+		err = archiveMessage(ctx, tx, tenant, id, completedBy, completedAt)
+		if err != nil {
+			return fmt.Errorf("failed to archive message: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM queue_messages
+		WHERE tenant = ?
+		  AND id = ?
+	`, tenant, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func archiveMessage(ctx context.Context, tx *sql.Tx, tenant string, id string, completedBy *string, completedAt time.Time) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT reserved_at, reserved_by, reserved_info, reserve_expires
+		FROM queue_message_reservations
+		WHERE message_id = ?
+		ORDER BY reserved_at ASC, id ASC
+	`, id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	reservations := []archivedReservation{}
+	for rows.Next() {
+		var reservation archivedReservation
+		var reservedAtStr string
+		var reserveExpiresStr string
+		err = rows.Scan(&reservedAtStr, &reservation.ReservedBy, &reservation.ReservedInfo, &reserveExpiresStr)
+		if err != nil {
+			return err
+		}
+
+		reservedAt, err := parseDateTime(reservedAtStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse reserved_at date: %w", err)
+		}
+
+		reserveExpires, err := parseDateTime(reserveExpiresStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse reserve_expires date: %w", err)
+		}
+
+		reservation.ReservedAt = reservedAt.UTC().Format("2006-01-02 15:04:05.999999")
+		reservation.ReserveExpires = reserveExpires.UTC().Format("2006-01-02 15:04:05.999999")
+		reservations = append(reservations, reservation)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	reservationsJson, err := json.Marshal(reservations)
+	if err != nil {
+		return err
+	}
+
+	// An id archived before in the tenant, such as an imported message, keeps
+	// its latest completion only
+	_, err = tx.ExecContext(ctx, `
+		REPLACE INTO queue_messages_archive (
+			id,
+			tenant,
+			name,
+			message,
+			attributes,
+			group_id,
+			expires_at,
+			published_at,
+			reserved_at,
+			reserved_by,
+			reserved_count,
+			reserved_info,
+			reserve_expires,
+			traceparent,
+			reservations,
+			completed_at,
+			completed_by
+		)
+		SELECT id, tenant, name, message, attributes, group_id, expires_at, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires, traceparent, ?, ?, COALESCE(?, reserved_by)
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND id = ?
+	`, string(reservationsJson), completedAt.UTC().Format("2006-01-02 15:04:05.999999"), completedBy, tenant, id)
+
+	return err
+}
+
+// SearchArchive lists up to limit archived messages of a queue matching
+// filter, most recently completed first, starting after cursor when set.
+func (repository *QueueRepository) SearchArchive(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	filter DomainEntities.ArchiveFilterEntity,
+	cursor *DomainEntities.MessageCursorEntity,
+	limit int,
+) ([]DomainEntities.ArchivedMessageEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.SearchArchive")
+	defer span.End()
+
+	conditions, args := archiveConditions(filter, cursor)
+	queryArgs := append([]interface{}{queueName.GetTenant(), queueName.GetValue()}, args...)
+	queryArgs = append(queryArgs, limit)
+
+	rows, err := repository.dbPool.QueryContext(ctx, `
+		SELECT id, message, attributes, group_id, expires_at, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires, traceparent, reservations, completed_at, completed_by
+		FROM queue_messages_archive
+		WHERE tenant = ?
+		  AND name = ?`+conditions+`
+		ORDER BY completed_at DESC, id DESC
+		LIMIT ?
+	`, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	archived := []DomainEntities.ArchivedMessageEntity{}
+	for rows.Next() {
+		var messageId string
+		var messageStr string
+		var attributesStr sql.NullString
+		var groupId *string
+		var expiresAtStr sql.NullString
+		var publishedAtStr string
+		var reservedAtStr sql.NullString
+		var reservedBy *string
+		var reservedCount *int
+		var reservedInfo *string
+		var reserveExpiresStr string
+		var traceparent *string
+		var reservationsStr sql.NullString
+		var completedAtStr string
+		var completedBy *string
+
+		err = rows.Scan(
+			&messageId,
+			&messageStr,
+			&attributesStr,
+			&groupId,
+			&expiresAtStr,
+			&publishedAtStr,
+			&reservedAtStr,
+			&reservedBy,
+			&reservedCount,
+			&reservedInfo,
+			&reserveExpiresStr,
+			&traceparent,
+			&reservationsStr,
+			&completedAtStr,
+			&completedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		publishedAt, err := parseDateTime(publishedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse published_at date: %w", err)
+		}
+
+		expiresAt, err := parseNullableDateTime(expiresAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse expires_at date: %w", err)
+		}
+
+		reservedAt, err := parseNullableDateTime(reservedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reserved_at date: %w", err)
+		}
+
+		reserveExpires, err := parseDateTime(reserveExpiresStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reserve_expires date: %w", err)
+		}
+
+		completedAt, err := parseDateTime(completedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse completed_at date: %w", err)
+		}
+
+		messageEntity := DomainEntities.RestoreQueueMessage(messageStr)
+
+		attributesEntity, err := parseAttributes(attributesStr)
+		if err != nil {
+			return nil, err
+		}
+
+		queueEntity := DomainEntities.RestoreQueue(
+			messageId,
+			queueName,
+			messageEntity,
+			*attributesEntity,
+			groupId,
+			expiresAt,
+			publishedAt,
+			reservedAt,
+			reservedBy,
+			reservedCount,
+			reservedInfo,
+			reserveExpires,
+			traceparent,
+		)
+
+		reservations, err := parseArchivedReservations(reservationsStr)
+		if err != nil {
+			return nil, err
+		}
+
+		archived = append(archived, *DomainEntities.NewArchivedMessage(*queueEntity, reservations, completedAt, completedBy))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return archived, nil
+}
+
+// archiveConditions returns the SQL conditions, each starting with AND,
+// and their arguments selecting the archived messages of filter listed after
+// cursor.
+func archiveConditions(filter DomainEntities.ArchiveFilterEntity, cursor *DomainEntities.MessageCursorEntity) (string, []interface{}) {
+	conditions := ""
+	args := []interface{}{}
+
+	if filter.GetMessageId() != nil {
+		conditions += " AND id = ?"
+		args = append(args, *filter.GetMessageId())
+	}
+
+	if filter.GetCompletedBy() != nil {
+		conditions += " AND completed_by = ?"
+		args = append(args, *filter.GetCompletedBy())
+	}
+
+	if filter.GetCompletedAfter() != nil {
+		conditions += " AND completed_at >= ?"
+		args = append(args, filter.GetCompletedAfter().UTC().Format("2006-01-02 15:04:05.999999"))
+	}
+
+	if filter.GetCompletedBefore() != nil {
+		conditions += " AND completed_at < ?"
+		args = append(args, filter.GetCompletedBefore().UTC().Format("2006-01-02 15:04:05.999999"))
+	}
+
+	// Archived attributes are only kept as JSON, with names restricted to
+	// characters that are safe in a quoted JSON path
+	for _, attributeFilter := range filter.GetAttributeFilters() {
+		path := `$."` + attributeFilter.GetName() + `"`
+		switch attributeFilter.GetOperator() {
+		case DomainEntities.AttributeEquals:
+			conditions += " AND JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ?"
+			args = append(args, path, attributeFilter.GetValue())
+		case DomainEntities.AttributeNotEquals:
+			conditions += " AND (JSON_EXTRACT(attributes, ?) IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) != ?)"
+			args = append(args, path, path, attributeFilter.GetValue())
+		case DomainEntities.AttributeExists:
+			conditions += " AND JSON_EXTRACT(attributes, ?) IS NOT NULL"
+			args = append(args, path)
+		case DomainEntities.AttributeNotExists:
+			conditions += " AND JSON_EXTRACT(attributes, ?) IS NULL"
+			args = append(args, path)
+		}
+	}
+
+	if filter.GetContains() != "" {
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(filter.GetContains())
+		conditions += " AND message LIKE ?"
+		args = append(args, "%"+escaped+"%")
+	}
+
+	if cursor != nil {
+		completedAtStr := cursor.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999")
+		conditions += " AND (completed_at < ? OR (completed_at = ? AND id < ?))"
+		args = append(args, completedAtStr, completedAtStr, cursor.GetId())
+	}
+
+	return conditions, args
+}
+
+func parseArchivedReservations(reservationsStr sql.NullString) ([]DomainEntities.MessageReservationEntity, error) {
+	reservations := []DomainEntities.MessageReservationEntity{}
+	if !reservationsStr.Valid || reservationsStr.String == "" {
+		return reservations, nil
+	}
+
+	var archivedReservations []archivedReservation
+	if err := json.Unmarshal([]byte(reservationsStr.String), &archivedReservations); err != nil {
+		return nil, fmt.Errorf("failed to parse reservations: %w", err)
+	}
+
+	for _, archivedReservation := range archivedReservations {
+		reservedAt, err := parseDateTime(archivedReservation.ReservedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reserved_at date: %w", err)
+		}
+
+		reserveExpires, err := parseDateTime(archivedReservation.ReserveExpires)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reserve_expires date: %w", err)
+		}
+
+		reservations = append(reservations, *DomainEntities.NewMessageReservation(reservedAt, archivedReservation.ReservedBy, archivedReservation.ReservedInfo, reserveExpires))
+	}
+
+	return reservations, nil
+}
+
+// RemoveArchivedBefore deletes up to limit archived messages of a queue
+// completed before completedBefore.
+func (repository *QueueRepository) RemoveArchivedBefore(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	completedBefore time.Time,
+	limit int,
+) (int64, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemoveArchivedBefore")
+	defer span.End()
+
+	result, err := repository.dbPool.ExecContext(ctx, `
+		DELETE FROM queue_messages_archive
+		WHERE tenant = ?
+		  AND name = ?
+		  AND completed_at < ?
+		ORDER BY completed_at ASC
+		LIMIT ?
+	`, queueName.GetTenant(), queueName.GetValue(), completedBefore.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
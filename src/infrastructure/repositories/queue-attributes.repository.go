@@ -0,0 +1,60 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"database/sql"
+	DomainEntities "lean-queue/src/domain/entities"
+	"strings"
+)
+
+// Attributes are kept as JSON on queue_messages for reading and copied to
+// queue_message_attributes, indexed, for filtering reservations.
+
+func insertAttributes(ctx context.Context, tx *sql.Tx, messageId string, attributes DomainEntities.MessageAttributesEntity) error {
+	if attributes.Len() == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, attributes.Len())
+	args := make([]interface{}, 0, attributes.Len()*3)
+	for name, value := range attributes.GetValues() {
+		placeholders = append(placeholders, "(?, ?, ?)")
+		args = append(args, messageId, name, value)
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO queue_message_attributes (message_id, attribute_name, attribute_value)
+		VALUES `+strings.Join(placeholders, ","), args...)
+
+	return err
+}
+
+// attributeFiltersCondition returns the SQL conditions, to be AND-ed to a
+// query on queue_messages, matching every filter.
+func attributeFiltersCondition(filters []DomainEntities.AttributeFilterEntity) (string, []interface{}) {
+	var condition strings.Builder
+	var args []interface{}
+
+	for _, filter := range filters {
+		switch filter.GetOperator() {
+		case DomainEntities.AttributeEquals:
+			condition.WriteString(`
+          AND EXISTS (SELECT 1 FROM queue_message_attributes a WHERE a.message_id = queue_messages.id AND a.attribute_name = ? AND a.attribute_value = ?)`)
+			args = append(args, filter.GetName(), filter.GetValue())
+		case DomainEntities.AttributeNotEquals:
+			condition.WriteString(`
+          AND NOT EXISTS (SELECT 1 FROM queue_message_attributes a WHERE a.message_id = queue_messages.id AND a.attribute_name = ? AND a.attribute_value = ?)`)
+			args = append(args, filter.GetName(), filter.GetValue())
+		case DomainEntities.AttributeExists:
+			condition.WriteString(`
+          AND EXISTS (SELECT 1 FROM queue_message_attributes a WHERE a.message_id = queue_messages.id AND a.attribute_name = ?)`)
+			args = append(args, filter.GetName())
+		case DomainEntities.AttributeNotExists:
+			condition.WriteString(`
+          AND NOT EXISTS (SELECT 1 FROM queue_message_attributes a WHERE a.message_id = queue_messages.id AND a.attribute_name = ?)`)
+			args = append(args, filter.GetName())
+		}
+	}
+
+	return condition.String(), args
+}
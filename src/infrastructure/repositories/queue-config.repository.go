@@ -0,0 +1,215 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"database/sql"
+	DomainEntities "lean-queue/src/domain/entities"
+	"time"
+)
+
+// Queue configs live next to the messages, so QueueRepository also
+// implements DomainRepositories.QueueConfigRepositoryInterface.
+
+func (repository *QueueRepository) GetConfig(ctx context.Context, queueName DomainEntities.QueueNameEntity) (*DomainEntities.QueueConfigEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetConfig")
+	defer span.End()
+
+	row := repository.dbPool.QueryRowContext(ctx, `
+		SELECT tenant, name, retention_seconds, max_length, max_receives, dead_letter_queue, events_queue, deduplication_window_seconds, default_ttl_seconds, expiration_policy, max_message_bytes, archive, archive_retention_seconds, paused
+		FROM queue_configs
+		WHERE tenant = ?
+		  AND name = ?
+	`, queueName.GetTenant(), queueName.GetValue())
+
+	config, err := scanQueueConfig(row)
+	if err == sql.ErrNoRows {
+		return DomainEntities.NewQueueConfig(queueName, 0, 0, 0, nil, nil, 0, 0, "", 0, false, 0, false)
+	}
+
+	return config, err
+}
+
+func (repository *QueueRepository) SaveConfig(ctx context.Context, config DomainEntities.QueueConfigEntity) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SaveConfig")
+	defer span.End()
+
+	_, err := repository.dbPool.ExecContext(ctx, `
+		INSERT INTO queue_configs (
+			tenant,
+			name,
+			retention_seconds,
+			max_length,
+			max_receives,
+			dead_letter_queue,
+			events_queue,
+			deduplication_window_seconds,
+			default_ttl_seconds,
+			expiration_policy,
+			max_message_bytes,
+			archive,
+			archive_retention_seconds,
+			paused,
+			updated_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			retention_seconds = VALUES(retention_seconds),
+			max_length = VALUES(max_length),
+			max_receives = VALUES(max_receives),
+			dead_letter_queue = VALUES(dead_letter_queue),
+			events_queue = VALUES(events_queue),
+			deduplication_window_seconds = VALUES(deduplication_window_seconds),
+			default_ttl_seconds = VALUES(default_ttl_seconds),
+			expiration_policy = VALUES(expiration_policy),
+			max_message_bytes = VALUES(max_message_bytes),
+			archive = VALUES(archive),
+			archive_retention_seconds = VALUES(archive_retention_seconds),
+			paused = VALUES(paused),
+			updated_at = VALUES(updated_at)
+	`,
+		config.GetName().GetTenant(),
+		config.GetName().GetValue(),
+		config.GetRetentionSeconds(),
+		config.GetMaxLength(),
+		config.GetMaxReceives(),
+		nullableQueueName(config.GetDeadLetterQueue()),
+		nullableQueueName(config.GetEventsQueue()),
+		config.GetDeduplicationWindowSeconds(),
+		config.GetDefaultTtlSeconds(),
+		string(config.GetExpirationPolicy()),
+		config.GetMaxMessageBytes(),
+		config.IsArchiving(),
+		config.GetArchiveRetentionSeconds(),
+		config.IsPaused(),
+		time.Now().UTC().Format("2006-01-02 15:04:05.999999"),
+	)
+
+	return err
+}
+
+// SetPaused only changes whether the queue is paused, so that it cannot undo
+// a config saved concurrently. A queue without a config gets the default one.
+func (repository *QueueRepository) SetPaused(ctx context.Context, queueName DomainEntities.QueueNameEntity, paused bool) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SetPaused")
+	defer span.End()
+
+	_, err := repository.dbPool.ExecContext(ctx, `
+		INSERT INTO queue_configs (tenant, name, paused, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			paused = VALUES(paused),
+			updated_at = VALUES(updated_at)
+	`,
+		queueName.GetTenant(),
+		queueName.GetValue(),
+		paused,
+		time.Now().UTC().Format("2006-01-02 15:04:05.999999"),
+	)
+
+	return err
+}
+
+func (repository *QueueRepository) ListConfigs(ctx context.Context) ([]DomainEntities.QueueConfigEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.ListConfigs")
+	defer span.End()
+
+	rows, err := repository.dbPool.QueryContext(ctx, `
+		SELECT tenant, name, retention_seconds, max_length, max_receives, dead_letter_queue, events_queue, deduplication_window_seconds, default_ttl_seconds, expiration_policy, max_message_bytes, archive, archive_retention_seconds, paused
+		FROM queue_configs
+		ORDER BY tenant, name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var configs []DomainEntities.QueueConfigEntity
+	for rows.Next() {
+		config, err := scanQueueConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, *config)
+	}
+
+	return configs, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanQueueConfig(row rowScanner) (*DomainEntities.QueueConfigEntity, error) {
+	var tenant string
+	var name string
+	var retentionSeconds int
+	var maxLength int
+	var maxReceives int
+	var deadLetterQueue sql.NullString
+	var eventsQueue sql.NullString
+	var deduplicationWindowSeconds int
+	var defaultTtlSeconds int
+	var expirationPolicy string
+	var maxMessageBytes int
+	var archive bool
+	var archiveRetentionSeconds int
+	var paused bool
+
+	err := row.Scan(
+		&tenant,
+		&name,
+		&retentionSeconds,
+		&maxLength,
+		&maxReceives,
+		&deadLetterQueue,
+		&eventsQueue,
+		&deduplicationWindowSeconds,
+		&defaultTtlSeconds,
+		&expirationPolicy,
+		&maxMessageBytes,
+		&archive,
+		&archiveRetentionSeconds,
+		&paused,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	nameEntity := DomainEntities.RestoreTenantQueueName(tenant, name)
+
+	deadLetterQueueEntity := nullableTenantQueueName(tenant, deadLetterQueue)
+	eventsQueueEntity := nullableTenantQueueName(tenant, eventsQueue)
+
+	return DomainEntities.NewQueueConfig(
+		nameEntity,
+		retentionSeconds,
+		maxLength,
+		maxReceives,
+		deadLetterQueueEntity,
+		eventsQueueEntity,
+		deduplicationWindowSeconds,
+		defaultTtlSeconds,
+		DomainEntities.ExpirationPolicy(expirationPolicy),
+		maxMessageBytes,
+		archive,
+		archiveRetentionSeconds,
+		paused,
+	)
+}
+
+func nullableQueueName(queueName *DomainEntities.QueueNameEntity) interface{} {
+	if queueName == nil {
+		return nil
+	}
+
+	return queueName.GetValue()
+}
+
+func nullableTenantQueueName(tenant string, value sql.NullString) *DomainEntities.QueueNameEntity {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+
+	queueName := DomainEntities.RestoreTenantQueueName(tenant, value.String)
+	return &queueName
+}
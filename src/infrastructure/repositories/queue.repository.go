@@ -1,10 +1,13 @@
 package InfrastructureRepositories
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	DomainEntities "lean-queue/src/domain/entities"
-	"log"
+	"log/slog"
+	"os"
 	"strings"
 	"time"
 
@@ -41,7 +44,7 @@ func NewQueueRepository(
 	repository.dbPool = repository.connect()
 
 	if err := repository.MigrateSchema(); err != nil {
-		log.Printf("Warning: Failed to migrate database schema: %v", err)
+		slog.Warn("failed to migrate database schema", "error", err)
 	}
 
 	return repository
@@ -58,7 +61,8 @@ func (repository *QueueRepository) connect() *sql.DB {
 
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("failed to open database", "error", err)
+		os.Exit(1)
 	}
 
 	db.SetMaxOpenConns(25)
@@ -68,6 +72,10 @@ func (repository *QueueRepository) connect() *sql.DB {
 	return db
 }
 
+func (repository *QueueRepository) Close() error {
+	return repository.dbPool.Close()
+}
+
 func parseDateTime(timeStr string) (time.Time, error) {
 	t, err := time.Parse("2006-01-02 15:04:05.999999", timeStr)
 	if err == nil {
@@ -100,7 +108,36 @@ func parseNullableDateTime(timeStr sql.NullString) (*time.Time, error) {
 	return &t, nil
 }
 
-func (repository *QueueRepository) Save(message DomainEntities.QueueEntity) error {
+func formatAttributes(attributes DomainEntities.MessageAttributesEntity) (interface{}, error) {
+	if attributes.Len() == 0 {
+		return nil, nil
+	}
+
+	attributesJson, err := json.Marshal(attributes.GetValues())
+	if err != nil {
+		return nil, err
+	}
+
+	return string(attributesJson), nil
+}
+
+func parseAttributes(attributesStr sql.NullString) (*DomainEntities.MessageAttributesEntity, error) {
+	values := map[string]string{}
+	if attributesStr.Valid && attributesStr.String != "" {
+		if err := json.Unmarshal([]byte(attributesStr.String), &values); err != nil {
+			return nil, fmt.Errorf("failed to parse attributes: %w", err)
+		}
+	}
+
+	attributes := DomainEntities.RestoreMessageAttributes(values)
+	return &attributes, nil
+}
+
+// Save saves the message, within the quota of tenant.
+func (repository *QueueRepository) Save(ctx context.Context, tenant DomainEntities.TenantEntity, message DomainEntities.QueueEntity) error {
+	ctx, span := startSpan(ctx, "QueueRepository.Save")
+	defer span.End()
+
 	connection := repository.connect()
 	defer connection.Close()
 
@@ -108,21 +145,260 @@ func (repository *QueueRepository) Save(message DomainEntities.QueueEntity) erro
 		return errors.New("database connection failed")
 	}
 
-	fmt.Printf("Message full representation: %+v\n", message)
+	tx, err := connection.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = checkTenantQuota(ctx, tx, tenant, 1, messagesBytes([]DomainEntities.QueueEntity{message}))
+	if err != nil {
+		return err
+	}
+
+	err = insertMessage(ctx, tx, message)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SaveMany saves all messages or none of them, within the quota of tenant.
+func (repository *QueueRepository) SaveMany(ctx context.Context, tenant DomainEntities.TenantEntity, messages []DomainEntities.QueueEntity) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SaveMany")
+	defer span.End()
+
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = checkTenantQuota(ctx, tx, tenant, int64(len(messages)), messagesBytes(messages))
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		err = insertMessage(ctx, tx, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SaveManyNew saves, all or none, the messages whose id is not stored yet,
+// within the quota of tenant, and returns how many were saved. An id stored
+// by another tenant fails with ErrMessageIdTaken, its message_id detail
+// naming it.
+func (repository *QueueRepository) SaveManyNew(ctx context.Context, tenant DomainEntities.TenantEntity, messages []DomainEntities.QueueEntity) (int64, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.SaveManyNew")
+	defer span.End()
+
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.GetId()
+	}
 
-	stmt, err := connection.Prepare(`
+	placeholders, args := inPlaceholders(ids)
+	existingIds, err := queryIds(ctx, tx, fmt.Sprintf(`
+		SELECT id
+		FROM queue_messages
+		WHERE id IN (%s)
+		  AND tenant = ?
+		FOR UPDATE
+	`, placeholders), append(args, tenant.GetId())...)
+	if err != nil {
+		return 0, err
+	}
+
+	takenIds, err := queryIds(ctx, tx, fmt.Sprintf(`
+		SELECT id
+		FROM queue_messages
+		WHERE id IN (%s)
+		  AND tenant <> ?
+		LIMIT 1
+	`, placeholders), append(args, tenant.GetId())...)
+	if err != nil {
+		return 0, err
+	}
+	if len(takenIds) > 0 {
+		return 0, DomainEntities.ErrMessageIdTaken.WithDetails(map[string]interface{}{"message_id": takenIds[0]})
+	}
+
+	existing := make(map[string]bool, len(existingIds))
+	for _, id := range existingIds {
+		existing[id] = true
+	}
+
+	newMessages := make([]DomainEntities.QueueEntity, 0, len(messages))
+	for _, message := range messages {
+		if existing[message.GetId()] {
+			continue
+		}
+		// A repeated id within the batch is saved once
+		existing[message.GetId()] = true
+		newMessages = append(newMessages, message)
+	}
+
+	err = checkTenantQuota(ctx, tx, tenant, int64(len(newMessages)), messagesBytes(newMessages))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range newMessages {
+		err = insertMessage(ctx, tx, message)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return int64(len(newMessages)), nil
+}
+
+// SaveDeduplicated saves the message unless another one was saved on the same
+// queue with deduplicationId before expiresAt, in which case it returns the
+// id of that original message and true. The quota of tenant only applies when
+// the message is saved, so a duplicate is answered even for a tenant over it.
+func (repository *QueueRepository) SaveDeduplicated(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	message DomainEntities.QueueEntity,
+	deduplicationId DomainEntities.DeduplicationIdEntity,
+	now time.Time,
+	expiresAt time.Time,
+) (string, bool, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.SaveDeduplicated")
+	defer span.End()
+
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM queue_deduplications
+		WHERE tenant = ?
+		  AND name = ?
+		  AND deduplication_id = ?
+		  AND expires_at < ?
+	`, message.GetName().GetTenant(), message.GetName().GetValue(), deduplicationId.GetValue(), now.UTC().Format("2006-01-02 15:04:05.999999"))
+	if err != nil {
+		return "", false, err
+	}
+
+	// The primary key serializes concurrent publishes of the same id
+	result, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO queue_deduplications (tenant, name, deduplication_id, message_id, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+	`,
+		message.GetName().GetTenant(),
+		message.GetName().GetValue(),
+		deduplicationId.GetValue(),
+		message.GetId(),
+		expiresAt.UTC().Format("2006-01-02 15:04:05.999999"),
+	)
+	if err != nil {
+		return "", false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return "", false, err
+	}
+
+	if inserted == 0 {
+		var originalId string
+		err = tx.QueryRowContext(ctx, `
+			SELECT message_id
+			FROM queue_deduplications
+			WHERE tenant = ?
+			  AND name = ?
+			  AND deduplication_id = ?
+		`, message.GetName().GetTenant(), message.GetName().GetValue(), deduplicationId.GetValue()).Scan(&originalId)
+		if err != nil {
+			return "", false, err
+		}
+
+		return originalId, true, tx.Commit()
+	}
+
+	// Only a message actually inserted counts against the quota
+	err = checkTenantQuota(ctx, tx, tenant, 1, messagesBytes([]DomainEntities.QueueEntity{message}))
+	if err != nil {
+		return "", false, err
+	}
+
+	err = insertMessage(ctx, tx, message)
+	if err != nil {
+		return "", false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", false, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return message.GetId(), false, nil
+}
+
+func (repository *QueueRepository) RemoveExpiredDeduplications(ctx context.Context, now time.Time, limit int) (int64, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemoveExpiredDeduplications")
+	defer span.End()
+
+	result, err := repository.dbPool.ExecContext(ctx, `
+		DELETE FROM queue_deduplications
+		WHERE expires_at < ?
+		LIMIT ?
+	`, now.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func insertMessage(ctx context.Context, tx *sql.Tx, message DomainEntities.QueueEntity) error {
+	slog.Debug("saving message",
+		"id", message.GetId(),
+		"queue_name", message.GetName().GetValue(),
+	)
+
+	stmt, err := tx.PrepareContext(ctx, `
         INSERT INTO queue_messages (
             id,
+            tenant,
             name,
             message,
+            attributes,
+            group_id,
+            expires_at,
             published_at,
             reserved_at,
             reserved_by,
             reserved_count,
             reserved_info,
-            reserve_expires
+            reserve_expires,
+            traceparent
         ) 
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `)
 	if err != nil {
 		return err
@@ -134,28 +410,55 @@ func (repository *QueueRepository) Save(message DomainEntities.QueueEntity) erro
 		reservedAtStr = message.GetReservedAt().UTC().Format("2006-01-02 15:04:05.999999")
 	}
 
+	var expiresAtStr interface{} = nil
+	if message.GetExpiresAt() != nil {
+		expiresAtStr = message.GetExpiresAt().UTC().Format("2006-01-02 15:04:05.999999")
+	}
+
 	publishedAtStr := message.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999")
 
-	_, err = stmt.Exec(
+	attributesJson, err := formatAttributes(message.GetAttributes())
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx,
 		message.GetId(),
+		message.GetName().GetTenant(),
 		message.GetName().GetValue(),
 		message.GetMessage().GetValue(),
+		attributesJson,
+		message.GetGroupId(),
+		expiresAtStr,
 		publishedAtStr,
 		reservedAtStr,
 		message.GetReservedBy(),
 		message.GetReservedCount(),
 		message.GetReservedInfo(),
 		message.GetReserveExpires().UTC().Format("2006-01-02 15:04:05.999999"),
+		message.GetTraceparent(),
 	)
+	if err != nil {
+		return err
+	}
+
+	err = insertAttributes(ctx, tx, message.GetId(), message.GetAttributes())
+	if err != nil {
+		return fmt.Errorf("failed to save attributes: %w", err)
+	}
 
-	return err
+	return nil
 }
 
-func (repository *QueueRepository) GetById(id string) (*DomainEntities.QueueEntity, error) {
-	stmt, err := repository.dbPool.Prepare(`
-        SELECT id, name, message, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires
+func (repository *QueueRepository) GetById(ctx context.Context, tenant string, id string) (*DomainEntities.QueueEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetById")
+	defer span.End()
+
+	stmt, err := repository.dbPool.PrepareContext(ctx, `
+        SELECT id, name, message, attributes, group_id, expires_at, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires, traceparent
         FROM queue_messages
-        WHERE id = ?
+        WHERE tenant = ?
+          AND id = ?
     `)
 	if err != nil {
 		return nil, err
@@ -165,23 +468,31 @@ func (repository *QueueRepository) GetById(id string) (*DomainEntities.QueueEnti
 	var messageId string
 	var name string
 	var message string
+	var attributesStr sql.NullString
+	var groupId *string
+	var expiresAtStr sql.NullString
 	var publishedAtStr string
 	var reservedAtStr sql.NullString
-	var reservedBy string
-	var reservedCount int
-	var reservedInfo string
+	var reservedBy *string
+	var reservedCount *int
+	var reservedInfo *string
 	var reserveExpiresStr sql.NullString
+	var traceparent *string
 
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRowContext(ctx, tenant, id).Scan(
 		&messageId,
 		&name,
 		&message,
+		&attributesStr,
+		&groupId,
+		&expiresAtStr,
 		&publishedAtStr,
 		&reservedAtStr,
 		&reservedBy,
 		&reservedCount,
 		&reservedInfo,
 		&reserveExpiresStr,
+		&traceparent,
 	)
 
 	if err != nil {
@@ -196,6 +507,11 @@ func (repository *QueueRepository) GetById(id string) (*DomainEntities.QueueEnti
 		return nil, fmt.Errorf("failed to parse published_at date: %w", err)
 	}
 
+	expiresAt, err := parseNullableDateTime(expiresAtStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expires_at date: %w", err)
+	}
+
 	reservedAt, err := parseNullableDateTime(reservedAtStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse reserved_at date: %w", err)
@@ -211,39 +527,101 @@ func (repository *QueueRepository) GetById(id string) (*DomainEntities.QueueEnti
 		reserveExpires = &defaultExpiry
 	}
 
-	nameEntity, err := DomainEntities.NewQueueName(name)
-	if err != nil {
-		return nil, err
-	}
+	nameEntity := DomainEntities.RestoreTenantQueueName(tenant, name)
 
-	messageEntity, err := DomainEntities.NewQueueMessage(message)
+	messageEntity := DomainEntities.RestoreQueueMessage(message)
+
+	attributesEntity, err := parseAttributes(attributesStr)
 	if err != nil {
 		return nil, err
 	}
 
-	queueEntity, err := DomainEntities.NewQueue(
-		&messageId,
-		*nameEntity,
-		*messageEntity,
+	queueEntity := DomainEntities.RestoreQueue(
+		messageId,
+		nameEntity,
+		messageEntity,
+		*attributesEntity,
+		groupId,
+		expiresAt,
 		publishedAt,
 		reservedAt,
-		&reservedBy,
-		&reservedCount,
-		&reservedInfo,
+		reservedBy,
+		reservedCount,
+		reservedInfo,
 		*reserveExpires,
+		traceparent,
 	)
 
-	return queueEntity, err
+	return queueEntity, nil
+}
+
+// GetReservations returns the reservation history of a message, oldest first.
+func (repository *QueueRepository) GetReservations(ctx context.Context, tenant string, id string) ([]DomainEntities.MessageReservationEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetReservations")
+	defer span.End()
+
+	rows, err := repository.dbPool.QueryContext(ctx, `
+        SELECT r.reserved_at, r.reserved_by, r.reserved_info, r.reserve_expires
+        FROM queue_message_reservations r
+        JOIN queue_messages m ON m.id = r.message_id
+        WHERE m.tenant = ?
+          AND r.message_id = ?
+        ORDER BY r.reserved_at ASC, r.id ASC
+    `, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := []DomainEntities.MessageReservationEntity{}
+	for rows.Next() {
+		var reservedAtStr string
+		var reservedBy *string
+		var reservedInfo *string
+		var reserveExpiresStr string
+
+		err = rows.Scan(&reservedAtStr, &reservedBy, &reservedInfo, &reserveExpiresStr)
+		if err != nil {
+			return nil, err
+		}
+
+		reservedAt, err := parseDateTime(reservedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reserved_at date: %w", err)
+		}
+
+		reserveExpires, err := parseDateTime(reserveExpiresStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reserve_expires date: %w", err)
+		}
+
+		reservations = append(reservations, *DomainEntities.NewMessageReservation(reservedAt, reservedBy, reservedInfo, reserveExpires))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
 }
 
+// GetMessages lists up to limit messages of a queue matching filter, in
+// publish order, starting after cursor when set.
 func (repository *QueueRepository) GetMessages(
+	ctx context.Context,
 	queueName DomainEntities.QueueNameEntity,
+	filter DomainEntities.MessageListFilterEntity,
+	cursor *DomainEntities.MessageCursorEntity,
 	limit int,
+	now time.Time,
 ) ([]DomainEntities.QueueEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetMessages")
+	defer span.End()
+
 	connection := repository.connect()
 	defer connection.Close()
 
-	tx, err := connection.Begin()
+	tx, err := connection.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("could not begin transaction: %w", err)
 	}
@@ -253,11 +631,21 @@ func (repository *QueueRepository) GetMessages(
 		}
 	}()
 
-	stmt, err := tx.Prepare(`
-        SELECT id, name, message, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires
+	conditions, args := messageListConditions(filter, cursor, now)
+	queryArgs := append([]interface{}{queueName.GetTenant(), queueName.GetValue()}, args...)
+	queryArgs = append(queryArgs, limit)
+
+	order := "ASC"
+	if filter.IsDescending() {
+		order = "DESC"
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+        SELECT id, name, message, attributes, group_id, expires_at, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires, traceparent
         FROM queue_messages
-        WHERE name = ? 
-        ORDER BY published_at ASC
+        WHERE tenant = ?
+          AND name = ?`+conditions+`
+        ORDER BY published_at `+order+`, id `+order+`
         LIMIT ?
     `)
 	if err != nil {
@@ -265,7 +653,7 @@ func (repository *QueueRepository) GetMessages(
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.Query(queueName.GetValue(), limit)
+	rows, err := stmt.QueryContext(ctx, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -277,23 +665,31 @@ func (repository *QueueRepository) GetMessages(
 		var messageId string
 		var nameStr string
 		var messageStr string
+		var attributesStr sql.NullString
+		var groupId *string
+		var expiresAtStr sql.NullString
 		var publishedAtStr string
 		var reservedAtStr sql.NullString
 		var reservedBy *string
 		var reservedCount *int
 		var reservedInfo *string
 		var reserveExpiresStr sql.NullString
+		var traceparent *string
 
 		err = rows.Scan(
 			&messageId,
 			&nameStr,
 			&messageStr,
+			&attributesStr,
+			&groupId,
+			&expiresAtStr,
 			&publishedAtStr,
 			&reservedAtStr,
 			&reservedBy,
 			&reservedCount,
 			&reservedInfo,
 			&reserveExpiresStr,
+			&traceparent,
 		)
 		if err != nil {
 			return nil, err
@@ -304,12 +700,16 @@ func (repository *QueueRepository) GetMessages(
 			return nil, fmt.Errorf("failed to parse published_at date: %w", err)
 		}
 
-		nameEntity, err := DomainEntities.NewQueueName(nameStr)
+		expiresAt, err := parseNullableDateTime(expiresAtStr)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to parse expires_at date: %w", err)
 		}
 
-		messageEntity, err := DomainEntities.NewQueueMessage(messageStr)
+		nameEntity := DomainEntities.RestoreTenantQueueName(queueName.GetTenant(), nameStr)
+
+		messageEntity := DomainEntities.RestoreQueueMessage(messageStr)
+
+		attributesEntity, err := parseAttributes(attributesStr)
 		if err != nil {
 			return nil, err
 		}
@@ -329,24 +729,22 @@ func (repository *QueueRepository) GetMessages(
 			return nil, fmt.Errorf("failed to parse reserve_expires date: %w", err)
 		}
 
-		*reservedCount = *reservedCount + 1
-
-		queueEntity, err := DomainEntities.NewQueue(
-			&messageId,
-			*nameEntity,
-			*messageEntity,
+		queueEntity := DomainEntities.RestoreQueue(
+			messageId,
+			nameEntity,
+			messageEntity,
+			*attributesEntity,
+			groupId,
+			expiresAt,
 			publishedAt,
 			reservedAt,
 			reservedBy,
 			reservedCount,
 			reservedInfo,
 			*reserveExpires,
+			traceparent,
 		)
 
-		if err != nil {
-			return nil, err
-		}
-
 		messages = append(messages, *queueEntity)
 	}
 
@@ -357,19 +755,86 @@ func (repository *QueueRepository) GetMessages(
 	return messages, nil
 }
 
+// messageListConditions returns the SQL conditions, each starting with AND,
+// and their arguments selecting the messages of filter listed after cursor.
+func messageListConditions(filter DomainEntities.MessageListFilterEntity, cursor *DomainEntities.MessageCursorEntity, now time.Time) (string, []interface{}) {
+	nowStr := now.UTC().Format("2006-01-02 15:04:05.999999")
+
+	conditions := ""
+	args := []interface{}{}
+
+	if len(filter.GetStates()) > 0 {
+		stateConditions := make([]string, 0, len(filter.GetStates()))
+		for _, state := range filter.GetStates() {
+			switch state {
+			case DomainEntities.MessageStateVisible:
+				stateConditions = append(stateConditions, "((expires_at IS NULL OR expires_at > ?) AND reserve_expires < ?)")
+				args = append(args, nowStr, nowStr)
+			case DomainEntities.MessageStateInFlight:
+				stateConditions = append(stateConditions, "((expires_at IS NULL OR expires_at > ?) AND reserve_expires >= ? AND reserved_at IS NOT NULL)")
+				args = append(args, nowStr, nowStr)
+			case DomainEntities.MessageStateDelayed:
+				stateConditions = append(stateConditions, "((expires_at IS NULL OR expires_at > ?) AND reserve_expires >= ? AND reserved_at IS NULL)")
+				args = append(args, nowStr, nowStr)
+			case DomainEntities.MessageStateExpired:
+				stateConditions = append(stateConditions, "(expires_at <= ?)")
+				args = append(args, nowStr)
+			}
+		}
+		conditions += " AND (" + strings.Join(stateConditions, " OR ") + ")"
+	}
+
+	if filter.GetReservedBy() != nil {
+		conditions += " AND reserved_by = ?"
+		args = append(args, *filter.GetReservedBy())
+	}
+
+	if filter.GetPublishedAfter() != nil {
+		conditions += " AND published_at >= ?"
+		args = append(args, filter.GetPublishedAfter().UTC().Format("2006-01-02 15:04:05.999999"))
+	}
+
+	if filter.GetPublishedBefore() != nil {
+		conditions += " AND published_at < ?"
+		args = append(args, filter.GetPublishedBefore().UTC().Format("2006-01-02 15:04:05.999999"))
+	}
+
+	if filter.GetMinReservedCount() > 0 {
+		conditions += " AND reserved_count >= ?"
+		args = append(args, filter.GetMinReservedCount())
+	}
+
+	if cursor != nil {
+		comparison := ">"
+		if filter.IsDescending() {
+			comparison = "<"
+		}
+		publishedAtStr := cursor.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999")
+		conditions += " AND (published_at " + comparison + " ? OR (published_at = ? AND id " + comparison + " ?))"
+		args = append(args, publishedAtStr, publishedAtStr, cursor.GetId())
+	}
+
+	return conditions, args
+}
+
 func (repository *QueueRepository) GetAndReserveMessages(
+	ctx context.Context,
 	queueName DomainEntities.QueueNameEntity,
 	limit int,
+	filters []DomainEntities.AttributeFilterEntity,
 	messagesBefore time.Time,
 	updateReservedAt time.Time,
 	updateReservedBy string,
 	updateReservedInfo *string,
 	updateReservedExpires *time.Time,
 ) ([]DomainEntities.QueueEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetAndReserveMessages")
+	defer span.End()
+
 	connection := repository.connect()
 	defer connection.Close()
 
-	tx, err := connection.Begin()
+	tx, err := connection.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("could not begin transaction: %w", err)
 	}
@@ -379,11 +844,35 @@ func (repository *QueueRepository) GetAndReserveMessages(
 		}
 	}()
 
-	stmt, err := tx.Prepare(`
-        SELECT id, name, message, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires
+	filtersCondition, filtersArgs := attributeFiltersCondition(filters)
+
+	stmt, err := tx.PrepareContext(ctx, `
+        SELECT id, name, message, attributes, group_id, expires_at, published_at, reserved_at, reserved_by, reserved_count, reserved_info, reserve_expires, traceparent
         FROM queue_messages
-        WHERE name = ? 
+        WHERE tenant = ?
+          AND name = ?
           AND reserve_expires < ?
+          AND (expires_at IS NULL OR expires_at > ?)`+filtersCondition+`
+          AND NOT EXISTS (
+            SELECT 1
+            FROM queue_configs
+            WHERE queue_configs.tenant = queue_messages.tenant
+              AND queue_configs.name = queue_messages.name
+              AND queue_configs.paused = 1
+          )
+          AND (
+            group_id IS NULL
+            OR NOT EXISTS (
+              SELECT 1
+              FROM queue_messages earlier
+              WHERE earlier.tenant = queue_messages.tenant
+                AND earlier.name = queue_messages.name
+                AND earlier.group_id = queue_messages.group_id
+                AND (earlier.expires_at IS NULL OR earlier.expires_at > ?)
+                AND (earlier.published_at < queue_messages.published_at
+                  OR (earlier.published_at = queue_messages.published_at AND earlier.id < queue_messages.id))
+            )
+          )
         ORDER BY published_at ASC
         LIMIT ?
         FOR UPDATE
@@ -393,7 +882,12 @@ func (repository *QueueRepository) GetAndReserveMessages(
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.Query(queueName.GetValue(), messagesBefore.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+	messagesBeforeStr := messagesBefore.UTC().Format("2006-01-02 15:04:05.999999")
+	queryArgs := []interface{}{queueName.GetTenant(), queueName.GetValue(), messagesBeforeStr, messagesBeforeStr}
+	queryArgs = append(queryArgs, filtersArgs...)
+	queryArgs = append(queryArgs, messagesBeforeStr, limit)
+
+	rows, err := stmt.QueryContext(ctx, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -406,23 +900,31 @@ func (repository *QueueRepository) GetAndReserveMessages(
 		var messageId string
 		var nameStr string
 		var messageStr string
+		var attributesStr sql.NullString
+		var groupId *string
+		var expiresAtStr sql.NullString
 		var publishedAtStr string
 		var reservedAtStr *string
 		var reservedBy *string
 		var reservedCount *int
 		var reservedInfo *string
 		var reserveExpiresStr sql.NullString
+		var traceparent *string
 
 		err = rows.Scan(
 			&messageId,
 			&nameStr,
 			&messageStr,
+			&attributesStr,
+			&groupId,
+			&expiresAtStr,
 			&publishedAtStr,
 			&reservedAtStr,
 			&reservedBy,
 			&reservedCount,
 			&reservedInfo,
 			&reserveExpiresStr,
+			&traceparent,
 		)
 		if err != nil {
 			return nil, err
@@ -433,12 +935,16 @@ func (repository *QueueRepository) GetAndReserveMessages(
 			return nil, fmt.Errorf("failed to parse published_at date: %w", err)
 		}
 
-		nameEntity, err := DomainEntities.NewQueueName(nameStr)
+		expiresAt, err := parseNullableDateTime(expiresAtStr)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to parse expires_at date: %w", err)
 		}
 
-		messageEntity, err := DomainEntities.NewQueueMessage(messageStr)
+		nameEntity := DomainEntities.RestoreTenantQueueName(queueName.GetTenant(), nameStr)
+
+		messageEntity := DomainEntities.RestoreQueueMessage(messageStr)
+
+		attributesEntity, err := parseAttributes(attributesStr)
 		if err != nil {
 			return nil, err
 		}
@@ -450,22 +956,22 @@ func (repository *QueueRepository) GetAndReserveMessages(
 
 		*reservedCount = *reservedCount + 1
 
-		queueEntity, err := DomainEntities.NewQueue(
-			&messageId,
-			*nameEntity,
-			*messageEntity,
+		queueEntity := DomainEntities.RestoreQueue(
+			messageId,
+			nameEntity,
+			messageEntity,
+			*attributesEntity,
+			groupId,
+			expiresAt,
 			publishedAt,
 			&updateReservedAt,
 			&updateReservedBy,
 			reservedCount,
 			updateReservedInfo,
 			*updateReservedExpires,
+			traceparent,
 		)
 
-		if err != nil {
-			return nil, err
-		}
-
 		messages = append(messages, *queueEntity)
 		messageIds = append(messageIds, messageId)
 	}
@@ -506,11 +1012,25 @@ func (repository *QueueRepository) GetAndReserveMessages(
 		args = append(args, id)
 	}
 
-	_, err = tx.Exec(updateQuery, args...)
+	_, err = tx.ExecContext(ctx, updateQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update reserved status: %w", err)
 	}
 
+	historyValues := make([]string, len(messageIds))
+	historyArgs := make([]interface{}, 0, len(messageIds)*5)
+	for i, id := range messageIds {
+		historyValues[i] = "(?, ?, ?, ?, ?)"
+		historyArgs = append(historyArgs, id, args[0], updateReservedBy, updateReservedInfo, expiresAtStr)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO queue_message_reservations (message_id, reserved_at, reserved_by, reserved_info, reserve_expires)
+        VALUES `+strings.Join(historyValues, ","), historyArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save reservation history: %w", err)
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, fmt.Errorf("could not commit transaction: %w", err)
 	}
@@ -518,23 +1038,569 @@ func (repository *QueueRepository) GetAndReserveMessages(
 	return messages, nil
 }
 
-func (repository *QueueRepository) RemoveById(id string) error {
-	connection := repository.connect()
-	defer connection.Close()
+// PurgeMessages deletes up to limit messages of a queue, only those visible
+// at visibleAt and published before publishedBefore when they are set.
+func (repository *QueueRepository) PurgeMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	visibleAt *time.Time,
+	publishedBefore *time.Time,
+	limit int,
+) (int64, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.PurgeMessages")
+	defer span.End()
+
+	conditions := ""
+	args := []interface{}{queueName.GetTenant(), queueName.GetValue()}
+
+	if visibleAt != nil {
+		conditions += " AND reserve_expires < ?"
+		args = append(args, visibleAt.UTC().Format("2006-01-02 15:04:05.999999"))
+	}
 
-	stmt, err := connection.Prepare(`
+	if publishedBefore != nil {
+		conditions += " AND published_at < ?"
+		args = append(args, publishedBefore.UTC().Format("2006-01-02 15:04:05.999999"))
+	}
+
+	args = append(args, limit)
+
+	result, err := repository.dbPool.ExecContext(ctx, `
 		DELETE FROM queue_messages
-		WHERE id = ?
-	`)
+		WHERE tenant = ?
+		  AND name = ?`+conditions+`
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (repository *QueueRepository) GetQueueStats(ctx context.Context, queueName DomainEntities.QueueNameEntity, now time.Time) (*DomainEntities.QueueStatsEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetQueueStats")
+	defer span.End()
+
+	var messages int64
+	var visible int64
+	var inFlight int64
+	var delayed int64
+	var bytes int64
+	var oldestPublishedAtStr sql.NullString
+	var paused bool
+
+	nowStr := now.UTC().Format("2006-01-02 15:04:05.999999")
+
+	err := repository.dbPool.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(reserve_expires < ?), 0),
+			COALESCE(SUM(reserve_expires >= ? AND reserved_at IS NOT NULL), 0),
+			COALESCE(SUM(reserve_expires >= ? AND reserved_at IS NULL), 0),
+			COALESCE(SUM(LENGTH(message)), 0),
+			MIN(CASE WHEN reserve_expires < ? THEN published_at END),
+			COALESCE((
+				SELECT paused
+				FROM queue_configs
+				WHERE tenant = ?
+				  AND name = ?
+			), 0)
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND name = ?
+		  AND (expires_at IS NULL OR expires_at > ?)
+	`,
+		nowStr,
+		nowStr,
+		nowStr,
+		nowStr,
+		queueName.GetTenant(),
+		queueName.GetValue(),
+		queueName.GetTenant(),
+		queueName.GetValue(),
+		nowStr,
+	).Scan(&messages, &visible, &inFlight, &delayed, &bytes, &oldestPublishedAtStr, &paused)
+	if err != nil {
+		return nil, err
+	}
+
+	oldestPublishedAt, err := parseNullableDateTime(oldestPublishedAtStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse published_at date: %w", err)
+	}
+
+	return DomainEntities.NewQueueStats(queueName, messages, visible, inFlight, delayed, bytes, oldestPublishedAt, paused), nil
+}
+
+// ListQueueStats returns the stats of every queue of the tenant holding
+// messages or a config, ordered by name.
+func (repository *QueueRepository) ListQueueStats(ctx context.Context, tenant string, now time.Time) ([]DomainEntities.QueueStatsEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.ListQueueStats")
+	defer span.End()
+
+	nowStr := now.UTC().Format("2006-01-02 15:04:05.999999")
+
+	rows, err := repository.dbPool.QueryContext(ctx, `
+		SELECT
+			q.name,
+			COUNT(m.id),
+			COALESCE(SUM(m.reserve_expires < ?), 0),
+			COALESCE(SUM(m.reserve_expires >= ? AND m.reserved_at IS NOT NULL), 0),
+			COALESCE(SUM(m.reserve_expires >= ? AND m.reserved_at IS NULL), 0),
+			COALESCE(SUM(LENGTH(m.message)), 0),
+			MIN(CASE WHEN m.reserve_expires < ? THEN m.published_at END),
+			COALESCE(MAX(c.paused), 0)
+		FROM (
+			SELECT DISTINCT name FROM queue_messages WHERE tenant = ?
+			UNION
+			SELECT name FROM queue_configs WHERE tenant = ?
+		) q
+		LEFT JOIN queue_messages m
+			ON m.tenant = ?
+			AND m.name = q.name
+			AND (m.expires_at IS NULL OR m.expires_at > ?)
+		LEFT JOIN queue_configs c
+			ON c.tenant = ?
+			AND c.name = q.name
+		GROUP BY q.name
+		ORDER BY q.name
+	`, nowStr, nowStr, nowStr, nowStr, tenant, tenant, tenant, nowStr, tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []DomainEntities.QueueStatsEntity{}
+	for rows.Next() {
+		var name string
+		var messages int64
+		var visible int64
+		var inFlight int64
+		var delayed int64
+		var bytes int64
+		var oldestPublishedAtStr sql.NullString
+		var paused bool
+
+		err := rows.Scan(&name, &messages, &visible, &inFlight, &delayed, &bytes, &oldestPublishedAtStr, &paused)
+		if err != nil {
+			return nil, err
+		}
+
+		nameEntity := DomainEntities.RestoreTenantQueueName(tenant, name)
+
+		oldestPublishedAt, err := parseNullableDateTime(oldestPublishedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse published_at date: %w", err)
+		}
+
+		stats = append(stats, *DomainEntities.NewQueueStats(nameEntity, messages, visible, inFlight, delayed, bytes, oldestPublishedAt, paused))
+	}
+
+	return stats, rows.Err()
+}
+
+// ReleaseMessage makes a message still reserved by reservedBy visible
+// again at visibleAt, reporting whether the reservation was still held.
+func (repository *QueueRepository) ReleaseMessage(ctx context.Context, tenant string, id string, reservedBy string, visibleAt time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.ReleaseMessage")
+	defer span.End()
+
+	result, err := repository.dbPool.ExecContext(ctx, `
+		UPDATE queue_messages
+		SET reserve_expires = ?
+		WHERE tenant = ?
+		  AND id = ?
+		  AND reserved_by = ?
+	`, visibleAt.UTC().Format("2006-01-02 15:04:05.999999"), tenant, id, reservedBy)
+	if err != nil {
+		return false, err
+	}
+
+	released, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return released > 0, nil
+}
+
+// checkTenantQuota fails with ErrTenantQuotaExceeded when storing another
+// messages messages, totalling bytes bytes, would exceed the tenant quota.
+// It locks the usage row of the tenant until tx ends, so concurrent saves
+// are checked one after the other against the usage each one leaves.
+func checkTenantQuota(ctx context.Context, tx *sql.Tx, tenant DomainEntities.TenantEntity, messages int64, bytes int64) error {
+	if !tenant.HasQuota() || messages == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO tenant_usage (tenant, messages, bytes)
+		VALUES (?, 0, 0)
+	`, tenant.GetId())
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
 
-	_, err = stmt.Exec(id)
+	var storedMessages int64
+	var storedBytes int64
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT messages, bytes
+		FROM tenant_usage
+		WHERE tenant = ?
+		FOR UPDATE
+	`, tenant.GetId()).Scan(&storedMessages, &storedBytes)
 	if err != nil {
 		return err
 	}
 
+	if tenant.GetMaxMessages() > 0 && storedMessages+messages > tenant.GetMaxMessages() {
+		return DomainEntities.ErrTenantQuotaExceeded
+	}
+
+	if tenant.GetMaxBytes() > 0 && storedBytes+bytes > tenant.GetMaxBytes() {
+		return DomainEntities.ErrTenantQuotaExceeded
+	}
+
 	return nil
 }
+
+// messagesBytes returns the size of the payloads of messages.
+func messagesBytes(messages []DomainEntities.QueueEntity) int64 {
+	var bytes int64
+	for _, message := range messages {
+		bytes += int64(message.GetMessage().GetSize())
+	}
+
+	return bytes
+}
+
+func (repository *QueueRepository) RemovePublishedBefore(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	publishedBefore time.Time,
+	limit int,
+) ([]string, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemovePublishedBefore")
+	defer span.End()
+
+	return repository.removeSelected(ctx, `
+		SELECT id
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND name = ?
+		  AND published_at < ?
+		ORDER BY published_at ASC
+		LIMIT ?
+		FOR UPDATE
+	`, queueName.GetTenant(), queueName.GetValue(), publishedBefore.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+}
+
+func (repository *QueueRepository) TrimToLength(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	maxLength int,
+	limit int,
+) ([]string, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.TrimToLength")
+	defer span.End()
+
+	// Everything after the newest maxLength messages is beyond the limit
+	return repository.removeSelected(ctx, `
+		SELECT id
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND name = ?
+		ORDER BY published_at DESC
+		LIMIT ? OFFSET ?
+		FOR UPDATE
+	`, queueName.GetTenant(), queueName.GetValue(), limit, maxLength)
+}
+
+// removeSelected deletes, in one transaction, the ids returned by a locking
+// SELECT and returns them.
+func (repository *QueueRepository) removeSelected(ctx context.Context, selectQuery string, args ...interface{}) ([]string, error) {
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids, err := queryIds(ctx, tx, selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return ids, tx.Commit()
+	}
+
+	placeholders, idArgs := inPlaceholders(ids)
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM queue_messages WHERE id IN (%s)`, placeholders), idArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove messages: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (repository *QueueRepository) MoveExhaustedMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	maxReceives int,
+	deadLetterQueue DomainEntities.QueueNameEntity,
+	visibleBefore time.Time,
+	limit int,
+) ([]string, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.MoveExhaustedMessages")
+	defer span.End()
+
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Only messages whose last reservation expired are moved, never in-flight ones
+	ids, err := queryIds(ctx, tx, `
+		SELECT id
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND name = ?
+		  AND reserved_count >= ?
+		  AND reserve_expires < ?
+		ORDER BY published_at ASC
+		LIMIT ?
+		FOR UPDATE
+	`, queueName.GetTenant(), queueName.GetValue(), maxReceives, visibleBefore.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return ids, tx.Commit()
+	}
+
+	placeholders, idArgs := inPlaceholders(ids)
+	args := append([]interface{}{deadLetterQueue.GetValue()}, idArgs...)
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE queue_messages
+		SET name = ?,
+			reserved_count = 0
+		WHERE id IN (%s)
+	`, placeholders), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move messages to dead letter queue: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return ids, nil
+}
+
+// RedriveMessages moves up to limit messages of queueName, typically a dead
+// letter queue, that are not reserved back into target, oldest first, giving
+// them a fresh receive count.
+func (repository *QueueRepository) RedriveMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	target DomainEntities.QueueNameEntity,
+	visibleBefore time.Time,
+	limit int,
+) ([]string, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RedriveMessages")
+	defer span.End()
+
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids, err := queryIds(ctx, tx, `
+		SELECT id
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND name = ?
+		  AND reserve_expires < ?
+		ORDER BY published_at ASC
+		LIMIT ?
+		FOR UPDATE
+	`, queueName.GetTenant(), queueName.GetValue(), visibleBefore.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return ids, tx.Commit()
+	}
+
+	placeholders, idArgs := inPlaceholders(ids)
+	args := append([]interface{}{target.GetValue()}, idArgs...)
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE queue_messages
+		SET name = ?,
+			reserved_count = 0
+		WHERE id IN (%s)
+	`, placeholders), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redrive messages: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return ids, nil
+}
+
+func queryIds(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func inPlaceholders(ids []string) (string, []interface{}) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	return strings.Join(placeholders, ","), args
+}
+
+// GetQueuesWithExpiredMessages returns up to limit queues holding expired
+// messages that are not reserved. Expired messages moved to a dead letter
+// queue wait for their reservation to end, so queues holding only reserved
+// ones would otherwise fill the limit on every run and starve the others.
+func (repository *QueueRepository) GetQueuesWithExpiredMessages(ctx context.Context, now time.Time, limit int) ([]DomainEntities.QueueNameEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetQueuesWithExpiredMessages")
+	defer span.End()
+
+	rows, err := repository.dbPool.QueryContext(ctx, `
+		SELECT DISTINCT tenant, name
+		FROM queue_messages
+		WHERE expires_at <= ?
+		  AND reserve_expires < ?
+		LIMIT ?
+	`, now.UTC().Format("2006-01-02 15:04:05.999999"), now.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var queueNames []DomainEntities.QueueNameEntity
+	for rows.Next() {
+		var tenant string
+		var name string
+		if err := rows.Scan(&tenant, &name); err != nil {
+			return nil, err
+		}
+
+		queueName := DomainEntities.RestoreTenantQueueName(tenant, name)
+		queueNames = append(queueNames, queueName)
+	}
+
+	return queueNames, rows.Err()
+}
+
+func (repository *QueueRepository) RemoveExpired(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	now time.Time,
+	limit int,
+) ([]string, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemoveExpired")
+	defer span.End()
+
+	return repository.removeSelected(ctx, `
+		SELECT id
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND name = ?
+		  AND expires_at <= ?
+		ORDER BY expires_at ASC
+		LIMIT ?
+		FOR UPDATE
+	`, queueName.GetTenant(), queueName.GetValue(), now.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+}
+
+func (repository *QueueRepository) MoveExpiredMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	deadLetterQueue DomainEntities.QueueNameEntity,
+	now time.Time,
+	limit int,
+) ([]string, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.MoveExpiredMessages")
+	defer span.End()
+
+	tx, err := repository.dbPool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// As for exhausted messages, in-flight ones are left to their consumer
+	// and only moved once their reservation expires
+	ids, err := queryIds(ctx, tx, `
+		SELECT id
+		FROM queue_messages
+		WHERE tenant = ?
+		  AND name = ?
+		  AND expires_at <= ?
+		  AND reserve_expires < ?
+		ORDER BY expires_at ASC
+		LIMIT ?
+		FOR UPDATE
+	`, queueName.GetTenant(), queueName.GetValue(), now.UTC().Format("2006-01-02 15:04:05.999999"), now.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return ids, tx.Commit()
+	}
+
+	// The time to live does not follow the message, or the dead letter queue
+	// would expire it right away
+	placeholders, idArgs := inPlaceholders(ids)
+	args := append([]interface{}{deadLetterQueue.GetValue()}, idArgs...)
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE queue_messages
+		SET name = ?,
+			expires_at = NULL
+		WHERE id IN (%s)
+	`, placeholders), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move messages to dead letter queue: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return ids, nil
+}
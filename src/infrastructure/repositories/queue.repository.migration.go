@@ -1,6 +1,7 @@
 package InfrastructureRepositories
 
 import (
+	"sort"
 	"time"
 )
 
@@ -40,16 +41,208 @@ func (repository *QueueRepository) MigrateSchema() error {
         ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
 		// Change message TEXT to LONGTEXT
 		2: `ALTER TABLE queue_messages MODIFY message LONGTEXT NOT NULL;`,
+		// Scope queue names per tenant
+		3: `ALTER TABLE queue_messages
+            ADD COLUMN tenant VARCHAR(64) NOT NULL DEFAULT '' AFTER id,
+            ADD INDEX idx_tenant_name_published_at (tenant, name, published_at);`,
+		// Per-queue policies enforced by the janitor
+		4: `CREATE TABLE IF NOT EXISTS queue_configs (
+            tenant VARCHAR(64) NOT NULL DEFAULT '',
+            name VARCHAR(255) NOT NULL,
+            retention_seconds INT NOT NULL DEFAULT 0,
+            max_length INT NOT NULL DEFAULT 0,
+            max_receives INT NOT NULL DEFAULT 0,
+            dead_letter_queue VARCHAR(255) NULL,
+            events_queue VARCHAR(255) NULL,
+            updated_at DATETIME(6) NOT NULL,
+            PRIMARY KEY (tenant, name)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// W3C trace context of the publish, handed to consumers
+		5: `ALTER TABLE queue_messages ADD COLUMN traceparent VARCHAR(55) NULL;`,
+		// Key/value metadata readable without parsing the message
+		6: `ALTER TABLE queue_messages ADD COLUMN attributes JSON NULL AFTER message;`,
+		// Indexed copy of the attributes used to filter reservations
+		7: `CREATE TABLE IF NOT EXISTS queue_message_attributes (
+            message_id VARCHAR(255) NOT NULL,
+            attribute_name VARCHAR(128) NOT NULL,
+            attribute_value VARCHAR(1024) NOT NULL,
+            PRIMARY KEY (message_id, attribute_name),
+            INDEX idx_attribute_name_value (attribute_name, attribute_value(191)),
+            FOREIGN KEY (message_id) REFERENCES queue_messages (id) ON DELETE CASCADE
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		8: `INSERT IGNORE INTO queue_message_attributes (message_id, attribute_name, attribute_value)
+            SELECT m.id, k.attribute_name, JSON_UNQUOTE(JSON_EXTRACT(m.attributes, CONCAT('$."', k.attribute_name, '"')))
+            FROM queue_messages m,
+                JSON_TABLE(JSON_KEYS(m.attributes), '$[*]' COLUMNS (attribute_name VARCHAR(128) PATH '$')) k
+            WHERE m.attributes IS NOT NULL;`,
+		// Publish deduplication ids remembered per queue for a window
+		9: `ALTER TABLE queue_configs ADD COLUMN deduplication_window_seconds INT NOT NULL DEFAULT 0;`,
+		10: `CREATE TABLE IF NOT EXISTS queue_deduplications (
+            tenant VARCHAR(64) NOT NULL DEFAULT '',
+            name VARCHAR(255) NOT NULL,
+            deduplication_id VARCHAR(128) NOT NULL,
+            message_id VARCHAR(255) NOT NULL,
+            expires_at DATETIME(6) NOT NULL,
+            PRIMARY KEY (tenant, name, deduplication_id),
+            INDEX idx_expires_at (expires_at)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// FIFO groups: only the oldest message of a group can be reserved
+		11: `ALTER TABLE queue_messages
+            ADD COLUMN group_id VARCHAR(128) NULL AFTER attributes,
+            ADD INDEX idx_tenant_name_group_published_at (tenant, name, group_id, published_at);`,
+		// Message time to live, with per-queue default and expiration policy
+		12: `ALTER TABLE queue_messages
+            ADD COLUMN expires_at DATETIME(6) NULL AFTER group_id,
+            ADD INDEX idx_expires_at (expires_at);`,
+		13: `ALTER TABLE queue_configs
+            ADD COLUMN default_ttl_seconds INT NOT NULL DEFAULT 0,
+            ADD COLUMN expiration_policy VARCHAR(16) NOT NULL DEFAULT 'delete';`,
+		// Topic fan-out to subscribed queues
+		14: `CREATE TABLE IF NOT EXISTS topic_subscriptions (
+            id VARCHAR(255) NOT NULL,
+            tenant VARCHAR(64) NOT NULL DEFAULT '',
+            topic VARCHAR(255) NOT NULL,
+            queue_name VARCHAR(255) NOT NULL,
+            filters JSON NULL,
+            created_at DATETIME(6) NOT NULL,
+            PRIMARY KEY (id),
+            UNIQUE INDEX idx_tenant_topic_queue_name (tenant, topic, queue_name)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// Push subscriptions deliver the messages of a queue to an http endpoint
+		15: `CREATE TABLE IF NOT EXISTS push_subscriptions (
+            id VARCHAR(255) NOT NULL,
+            tenant VARCHAR(64) NOT NULL DEFAULT '',
+            queue_name VARCHAR(255) NOT NULL,
+            url VARCHAR(2048) NOT NULL,
+            secret VARCHAR(255) NOT NULL,
+            max_concurrency INT NOT NULL DEFAULT 1,
+            timeout_seconds INT NOT NULL DEFAULT 30,
+            created_at DATETIME(6) NOT NULL,
+            PRIMARY KEY (id),
+            INDEX idx_tenant_queue_name (tenant, queue_name)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// Cron schedules publishing into queues
+		16: `CREATE TABLE IF NOT EXISTS schedules (
+            id VARCHAR(255) NOT NULL,
+            tenant VARCHAR(64) NOT NULL DEFAULT '',
+            name VARCHAR(255) NOT NULL,
+            cron_expression VARCHAR(255) NOT NULL,
+            time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+            queue_name VARCHAR(255) NOT NULL,
+            payload LONGTEXT NOT NULL,
+            attributes JSON NULL,
+            missed_run_policy VARCHAR(16) NOT NULL DEFAULT 'run_once',
+            paused TINYINT(1) NOT NULL DEFAULT 0,
+            next_run_at DATETIME(6) NOT NULL,
+            last_run_at DATETIME(6) NULL,
+            created_at DATETIME(6) NOT NULL,
+            PRIMARY KEY (id),
+            UNIQUE INDEX idx_tenant_name (tenant, name),
+            INDEX idx_paused_next_run_at (paused, next_run_at)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// Pausing consumption of a queue
+		17: `ALTER TABLE queue_configs
+            ADD COLUMN paused TINYINT(1) NOT NULL DEFAULT 0;`,
+		// Every reservation of a message, kept while the message exists
+		18: `CREATE TABLE IF NOT EXISTS queue_message_reservations (
+            id BIGINT NOT NULL AUTO_INCREMENT,
+            message_id VARCHAR(255) NOT NULL,
+            reserved_at DATETIME(6) NOT NULL,
+            reserved_by VARCHAR(255) NULL,
+            reserved_info TEXT NULL,
+            reserve_expires DATETIME(6) NOT NULL,
+            PRIMARY KEY (id),
+            INDEX idx_message_id_reserved_at (message_id, reserved_at),
+            FOREIGN KEY (message_id) REFERENCES queue_messages (id) ON DELETE CASCADE
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// Largest message a queue accepts, 0 meaning the global limit
+		19: `ALTER TABLE queue_configs
+            ADD COLUMN max_message_bytes INT NOT NULL DEFAULT 0;`,
+		// Optional archive of removed messages, kept for a per-queue retention
+		20: `ALTER TABLE queue_configs
+            ADD COLUMN archive TINYINT(1) NOT NULL DEFAULT 0,
+            ADD COLUMN archive_retention_seconds INT NOT NULL DEFAULT 0;`,
+		21: `CREATE TABLE IF NOT EXISTS queue_messages_archive (
+            id VARCHAR(255) NOT NULL,
+            tenant VARCHAR(64) NOT NULL DEFAULT '',
+            name VARCHAR(255) NOT NULL,
+            message LONGTEXT NOT NULL,
+            attributes JSON NULL,
+            group_id VARCHAR(128) NULL,
+            expires_at DATETIME(6) NULL,
+            published_at DATETIME(6) NOT NULL,
+            reserved_at DATETIME(6) NULL,
+            reserved_by VARCHAR(255) NULL,
+            reserved_count INT DEFAULT 0,
+            reserved_info TEXT NULL,
+            reserve_expires DATETIME(6) NOT NULL,
+            traceparent VARCHAR(55) NULL,
+            reservations JSON NULL,
+            completed_at DATETIME(6) NOT NULL,
+            completed_by VARCHAR(255) NULL,
+            PRIMARY KEY (id),
+            INDEX idx_tenant_name_completed_at (tenant, name, completed_at)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// Stored messages and bytes per tenant, checked against the tenant quota
+		22: `CREATE TABLE IF NOT EXISTS tenant_usage (
+            tenant VARCHAR(64) NOT NULL,
+            messages BIGINT NOT NULL DEFAULT 0,
+            bytes BIGINT NOT NULL DEFAULT 0,
+            PRIMARY KEY (tenant)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// Count the messages stored before the usage table existed
+		23: `INSERT INTO tenant_usage (tenant, messages, bytes)
+            SELECT tenant, COUNT(*), COALESCE(SUM(LENGTH(message)), 0)
+            FROM queue_messages
+            GROUP BY tenant
+            ON DUPLICATE KEY UPDATE messages = VALUES(messages), bytes = VALUES(bytes);`,
+		// Keep the usage up to date however messages are added
+		24: `CREATE TRIGGER queue_messages_usage_insert AFTER INSERT ON queue_messages
+            FOR EACH ROW
+            INSERT INTO tenant_usage (tenant, messages, bytes)
+            VALUES (NEW.tenant, 1, LENGTH(NEW.message))
+            ON DUPLICATE KEY UPDATE messages = messages + 1, bytes = bytes + LENGTH(NEW.message);`,
+		// and removed
+		25: `CREATE TRIGGER queue_messages_usage_delete AFTER DELETE ON queue_messages
+            FOR EACH ROW
+            UPDATE tenant_usage
+            SET messages = messages - 1, bytes = bytes - LENGTH(OLD.message)
+            WHERE tenant = OLD.tenant;`,
+		// Attribute names and values are case sensitive, as when filtering topic subscriptions
+		26: `ALTER TABLE queue_message_attributes
+            MODIFY attribute_name VARCHAR(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+            MODIFY attribute_value VARCHAR(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL;`,
+		// API keys created through the API, of which only a hash is kept
+		27: `CREATE TABLE IF NOT EXISTS api_keys (
+            name VARCHAR(128) NOT NULL,
+            tenant VARCHAR(64) NOT NULL DEFAULT '',
+            key_hash CHAR(64) NOT NULL,
+            admin TINYINT(1) NOT NULL DEFAULT 0,
+            created_at DATETIME(6) NOT NULL,
+            PRIMARY KEY (name),
+            UNIQUE INDEX idx_key_hash (key_hash)
+        ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+		// Imported messages keep their ids, so archived ids are only unique within a tenant
+		28: `ALTER TABLE queue_messages_archive
+            DROP PRIMARY KEY,
+            ADD PRIMARY KEY (tenant, id);`,
+	}
+
+	// Migrations depend on each other, so they must run in version order
+	versions := make([]int, 0, len(migrations))
+	for version := range migrations {
+		versions = append(versions, version)
 	}
+	sort.Ints(versions)
 
 	tx, err := connection.Begin()
 	if err != nil {
 		return err
 	}
 
-	for version, migration := range migrations {
+	for _, version := range versions {
 		if version > currentVersion {
-			_, err = tx.Exec(migration)
+			_, err = tx.Exec(migrations[version])
 			if err != nil {
 				tx.Rollback()
 				return err
@@ -0,0 +1,240 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
+	"time"
+)
+
+// QueueRepository also implements DomainRepositories.ScheduleRepositoryInterface.
+
+const scheduleColumns = `id, tenant, name, cron_expression, time_zone, queue_name, payload, attributes, missed_run_policy, paused, next_run_at, last_run_at, created_at`
+
+func (repository *QueueRepository) ListSchedules(ctx context.Context, tenant string) ([]DomainEntities.ScheduleEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.ListSchedules")
+	defer span.End()
+
+	return repository.querySchedules(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM schedules
+		WHERE tenant = ?
+		ORDER BY name ASC
+	`, tenant)
+}
+
+func (repository *QueueRepository) GetSchedule(ctx context.Context, tenant string, name string) (*DomainEntities.ScheduleEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetSchedule")
+	defer span.End()
+
+	schedules, err := repository.querySchedules(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM schedules
+		WHERE tenant = ?
+		  AND name = ?
+	`, tenant, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+
+	return &schedules[0], nil
+}
+
+func (repository *QueueRepository) SaveSchedule(ctx context.Context, schedule DomainEntities.ScheduleEntity) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SaveSchedule")
+	defer span.End()
+
+	attributes, err := formatAttributes(schedule.GetAttributes())
+	if err != nil {
+		return err
+	}
+
+	var lastRunAt *string
+	if schedule.GetLastRunAt() != nil {
+		lastRunAtStr := schedule.GetLastRunAt().UTC().Format("2006-01-02 15:04:05.999999")
+		lastRunAt = &lastRunAtStr
+	}
+
+	_, err = repository.dbPool.ExecContext(ctx, `
+		INSERT INTO schedules (`+scheduleColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			cron_expression = VALUES(cron_expression),
+			time_zone = VALUES(time_zone),
+			queue_name = VALUES(queue_name),
+			payload = VALUES(payload),
+			attributes = VALUES(attributes),
+			missed_run_policy = VALUES(missed_run_policy),
+			paused = VALUES(paused),
+			next_run_at = VALUES(next_run_at),
+			last_run_at = VALUES(last_run_at)
+	`,
+		schedule.GetId(),
+		schedule.GetQueueName().GetTenant(),
+		schedule.GetName(),
+		schedule.GetCronExpression(),
+		schedule.GetTimeZone(),
+		schedule.GetQueueName().GetValue(),
+		schedule.GetPayload(),
+		attributes,
+		string(schedule.GetMissedRunPolicy()),
+		schedule.IsPaused(),
+		schedule.GetNextRunAt().UTC().Format("2006-01-02 15:04:05.999999"),
+		lastRunAt,
+		schedule.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	)
+
+	return err
+}
+
+func (repository *QueueRepository) RemoveSchedule(ctx context.Context, tenant string, name string) (bool, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemoveSchedule")
+	defer span.End()
+
+	result, err := repository.dbPool.ExecContext(ctx, `
+		DELETE FROM schedules
+		WHERE tenant = ?
+		  AND name = ?
+	`, tenant, name)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, nil
+}
+
+func (repository *QueueRepository) GetDueSchedules(ctx context.Context, now time.Time, limit int) ([]DomainEntities.ScheduleEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetDueSchedules")
+	defer span.End()
+
+	return repository.querySchedules(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM schedules
+		WHERE paused = 0
+		  AND next_run_at <= ?
+		ORDER BY next_run_at ASC
+		LIMIT ?
+	`, now.UTC().Format("2006-01-02 15:04:05.999999"), limit)
+}
+
+func (repository *QueueRepository) SetScheduleRun(ctx context.Context, id string, lastRunAt *time.Time, nextRunAt time.Time) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SetScheduleRun")
+	defer span.End()
+
+	var lastRunAtStr *string
+	if lastRunAt != nil {
+		lastRunAtStrC := lastRunAt.UTC().Format("2006-01-02 15:04:05.999999")
+		lastRunAtStr = &lastRunAtStrC
+	}
+
+	_, err := repository.dbPool.ExecContext(ctx, `
+		UPDATE schedules
+		SET last_run_at = COALESCE(?, last_run_at),
+			next_run_at = ?
+		WHERE id = ?
+	`, lastRunAtStr, nextRunAt.UTC().Format("2006-01-02 15:04:05.999999"), id)
+
+	return err
+}
+
+func (repository *QueueRepository) querySchedules(ctx context.Context, query string, args ...interface{}) ([]DomainEntities.ScheduleEntity, error) {
+	rows, err := repository.dbPool.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []DomainEntities.ScheduleEntity{}
+	for rows.Next() {
+		var id string
+		var tenant string
+		var name string
+		var cronExpression string
+		var timeZone string
+		var queueName string
+		var payload string
+		var attributesStr sql.NullString
+		var missedRunPolicy string
+		var paused bool
+		var nextRunAtStr string
+		var lastRunAtStr sql.NullString
+		var createdAtStr string
+
+		err = rows.Scan(
+			&id,
+			&tenant,
+			&name,
+			&cronExpression,
+			&timeZone,
+			&queueName,
+			&payload,
+			&attributesStr,
+			&missedRunPolicy,
+			&paused,
+			&nextRunAtStr,
+			&lastRunAtStr,
+			&createdAtStr,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		nextRunAt, err := parseDateTime(nextRunAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next_run_at date: %w", err)
+		}
+
+		lastRunAt, err := parseNullableDateTime(lastRunAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse last_run_at date: %w", err)
+		}
+
+		createdAt, err := parseDateTime(createdAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse created_at date: %w", err)
+		}
+
+		queueNameEntity := DomainEntities.RestoreTenantQueueName(tenant, queueName)
+
+		attributes, err := parseAttributes(attributesStr)
+		if err != nil {
+			return nil, err
+		}
+
+		schedule, err := DomainEntities.NewSchedule(
+			&id,
+			name,
+			cronExpression,
+			timeZone,
+			queueNameEntity,
+			payload,
+			*attributes,
+			DomainEntities.MissedRunPolicy(missedRunPolicy),
+			paused,
+			nextRunAt,
+			lastRunAt,
+			createdAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, *schedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,173 @@
+package InfrastructureRepositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
+)
+
+// Topics are the set of their subscriptions, so QueueRepository also
+// implements DomainRepositories.TopicRepositoryInterface.
+
+func (repository *QueueRepository) GetTopic(ctx context.Context, tenant string, topic string) (*DomainEntities.TopicEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.GetTopic")
+	defer span.End()
+
+	topics, err := repository.queryTopics(ctx, `
+		SELECT id, topic, queue_name, filters, created_at
+		FROM topic_subscriptions
+		WHERE tenant = ?
+		  AND topic = ?
+		ORDER BY created_at ASC
+	`, tenant, tenant, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(topics) == 0 {
+		return nil, nil
+	}
+
+	return &topics[0], nil
+}
+
+func (repository *QueueRepository) ListTopics(ctx context.Context, tenant string) ([]DomainEntities.TopicEntity, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.ListTopics")
+	defer span.End()
+
+	return repository.queryTopics(ctx, `
+		SELECT id, topic, queue_name, filters, created_at
+		FROM topic_subscriptions
+		WHERE tenant = ?
+		ORDER BY topic ASC, created_at ASC
+	`, tenant, tenant)
+}
+
+func (repository *QueueRepository) SaveSubscription(ctx context.Context, tenant string, subscription DomainEntities.TopicSubscriptionEntity) error {
+	ctx, span := startSpan(ctx, "QueueRepository.SaveSubscription")
+	defer span.End()
+
+	expressions := make([]string, len(subscription.GetFilters()))
+	for i, filter := range subscription.GetFilters() {
+		expressions[i] = filter.String()
+	}
+
+	filtersJson, err := json.Marshal(expressions)
+	if err != nil {
+		return err
+	}
+
+	_, err = repository.dbPool.ExecContext(ctx, `
+		INSERT INTO topic_subscriptions (id, tenant, topic, queue_name, filters, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			filters = VALUES(filters)
+	`,
+		subscription.GetId(),
+		tenant,
+		subscription.GetTopic(),
+		subscription.GetQueueName().GetValue(),
+		string(filtersJson),
+		subscription.GetCreatedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+	)
+
+	return err
+}
+
+func (repository *QueueRepository) RemoveSubscription(ctx context.Context, tenant string, topic string, id string) (bool, error) {
+	ctx, span := startSpan(ctx, "QueueRepository.RemoveSubscription")
+	defer span.End()
+
+	result, err := repository.dbPool.ExecContext(ctx, `
+		DELETE FROM topic_subscriptions
+		WHERE tenant = ?
+		  AND topic = ?
+		  AND id = ?
+	`, tenant, topic, id)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, nil
+}
+
+// queryTopics groups the subscriptions returned by query, ordered by topic,
+// into topics of tenant.
+func (repository *QueueRepository) queryTopics(ctx context.Context, query string, tenant string, args ...interface{}) ([]DomainEntities.TopicEntity, error) {
+	rows, err := repository.dbPool.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var topicNames []string
+	subscriptionsByTopic := map[string][]DomainEntities.TopicSubscriptionEntity{}
+
+	for rows.Next() {
+		var id string
+		var topic string
+		var queueName string
+		var filtersStr sql.NullString
+		var createdAtStr string
+
+		err = rows.Scan(&id, &topic, &queueName, &filtersStr, &createdAtStr)
+		if err != nil {
+			return nil, err
+		}
+
+		createdAt, err := parseDateTime(createdAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse created_at date: %w", err)
+		}
+
+		var expressions []string
+		if filtersStr.Valid && filtersStr.String != "" {
+			if err := json.Unmarshal([]byte(filtersStr.String), &expressions); err != nil {
+				return nil, fmt.Errorf("failed to parse filters: %w", err)
+			}
+		}
+
+		filters := make([]DomainEntities.AttributeFilterEntity, 0, len(expressions))
+		for _, expression := range expressions {
+			filter, err := DomainEntities.ParseAttributeFilter(expression)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, *filter)
+		}
+
+		queueNameEntity := DomainEntities.RestoreTenantQueueName(tenant, queueName)
+
+		subscription, err := DomainEntities.NewTopicSubscription(&id, topic, queueNameEntity, filters, createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := subscriptionsByTopic[topic]; !ok {
+			topicNames = append(topicNames, topic)
+		}
+		subscriptionsByTopic[topic] = append(subscriptionsByTopic[topic], *subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	topics := make([]DomainEntities.TopicEntity, 0, len(topicNames))
+	for _, topicName := range topicNames {
+		topic, err := DomainEntities.NewTopic(tenant, topicName, subscriptionsByTopic[topicName])
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, *topic)
+	}
+
+	return topics, nil
+}
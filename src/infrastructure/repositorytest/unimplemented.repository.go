@@ -0,0 +1,281 @@
+package InfrastructureRepositoryTest
+
+import (
+	"context"
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureRepositories "lean-queue/src/infrastructure/repositories"
+	"time"
+)
+
+// UnimplementedRepository implements every repository interface, and TryLock
+// of the MySQL repository, failing every call. Test fakes embed it and
+// implement the methods their tests reach, so reaching another one fails the
+// request instead of panicking.
+type UnimplementedRepository struct{}
+
+var (
+	_ DomainRepositories.QueueRepositoryInterface            = UnimplementedRepository{}
+	_ DomainRepositories.QueueConfigRepositoryInterface      = UnimplementedRepository{}
+	_ DomainRepositories.QueueArchiveRepositoryInterface     = UnimplementedRepository{}
+	_ DomainRepositories.TopicRepositoryInterface            = UnimplementedRepository{}
+	_ DomainRepositories.PushSubscriptionRepositoryInterface = UnimplementedRepository{}
+	_ DomainRepositories.ScheduleRepositoryInterface         = UnimplementedRepository{}
+	_ DomainRepositories.ApiKeyRepositoryInterface           = UnimplementedRepository{}
+)
+
+func errUnimplemented(method string) error {
+	return fmt.Errorf("%s is not implemented by the test repository", method)
+}
+
+func (UnimplementedRepository) Save(ctx context.Context, tenant DomainEntities.TenantEntity, message DomainEntities.QueueEntity) error {
+	return errUnimplemented("Save")
+}
+
+func (UnimplementedRepository) SaveMany(ctx context.Context, tenant DomainEntities.TenantEntity, messages []DomainEntities.QueueEntity) error {
+	return errUnimplemented("SaveMany")
+}
+
+func (UnimplementedRepository) SaveManyNew(ctx context.Context, tenant DomainEntities.TenantEntity, messages []DomainEntities.QueueEntity) (int64, error) {
+	return 0, errUnimplemented("SaveManyNew")
+}
+
+func (UnimplementedRepository) SaveDeduplicated(
+	ctx context.Context,
+	tenant DomainEntities.TenantEntity,
+	message DomainEntities.QueueEntity,
+	deduplicationId DomainEntities.DeduplicationIdEntity,
+	now time.Time,
+	expiresAt time.Time,
+) (string, bool, error) {
+	return "", false, errUnimplemented("SaveDeduplicated")
+}
+
+func (UnimplementedRepository) RemoveExpiredDeduplications(ctx context.Context, now time.Time, limit int) (int64, error) {
+	return 0, errUnimplemented("RemoveExpiredDeduplications")
+}
+
+func (UnimplementedRepository) GetById(ctx context.Context, tenant string, id string) (*DomainEntities.QueueEntity, error) {
+	return nil, errUnimplemented("GetById")
+}
+
+func (UnimplementedRepository) GetReservations(ctx context.Context, tenant string, id string) ([]DomainEntities.MessageReservationEntity, error) {
+	return nil, errUnimplemented("GetReservations")
+}
+
+func (UnimplementedRepository) GetAndReserveMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	limit int,
+	filters []DomainEntities.AttributeFilterEntity,
+	messagesBefore time.Time,
+	updateReservedAt time.Time,
+	updateReservedBy string,
+	updateReservedInfo *string,
+	updateReservedExpires *time.Time,
+) ([]DomainEntities.QueueEntity, error) {
+	return nil, errUnimplemented("GetAndReserveMessages")
+}
+
+func (UnimplementedRepository) GetMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	filter DomainEntities.MessageListFilterEntity,
+	cursor *DomainEntities.MessageCursorEntity,
+	limit int,
+	now time.Time,
+) ([]DomainEntities.QueueEntity, error) {
+	return nil, errUnimplemented("GetMessages")
+}
+
+func (UnimplementedRepository) CompleteById(
+	ctx context.Context,
+	tenant string,
+	id string,
+	completedBy *string,
+	completedAt time.Time,
+) error {
+	return errUnimplemented("CompleteById")
+}
+
+func (UnimplementedRepository) ReleaseMessage(
+	ctx context.Context,
+	tenant string,
+	id string,
+	reservedBy string,
+	visibleAt time.Time,
+) (bool, error) {
+	return false, errUnimplemented("ReleaseMessage")
+}
+
+func (UnimplementedRepository) PurgeMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	visibleAt *time.Time,
+	publishedBefore *time.Time,
+	limit int,
+) (int64, error) {
+	return 0, errUnimplemented("PurgeMessages")
+}
+
+func (UnimplementedRepository) GetQueueStats(ctx context.Context, queueName DomainEntities.QueueNameEntity, now time.Time) (*DomainEntities.QueueStatsEntity, error) {
+	return nil, errUnimplemented("GetQueueStats")
+}
+
+func (UnimplementedRepository) ListQueueStats(ctx context.Context, tenant string, now time.Time) ([]DomainEntities.QueueStatsEntity, error) {
+	return nil, errUnimplemented("ListQueueStats")
+}
+
+func (UnimplementedRepository) RemovePublishedBefore(ctx context.Context, queueName DomainEntities.QueueNameEntity, publishedBefore time.Time, limit int) ([]string, error) {
+	return nil, errUnimplemented("RemovePublishedBefore")
+}
+
+func (UnimplementedRepository) TrimToLength(ctx context.Context, queueName DomainEntities.QueueNameEntity, maxLength int, limit int) ([]string, error) {
+	return nil, errUnimplemented("TrimToLength")
+}
+
+func (UnimplementedRepository) MoveExhaustedMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	maxReceives int,
+	deadLetterQueue DomainEntities.QueueNameEntity,
+	visibleBefore time.Time,
+	limit int,
+) ([]string, error) {
+	return nil, errUnimplemented("MoveExhaustedMessages")
+}
+
+func (UnimplementedRepository) RedriveMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	target DomainEntities.QueueNameEntity,
+	visibleBefore time.Time,
+	limit int,
+) ([]string, error) {
+	return nil, errUnimplemented("RedriveMessages")
+}
+
+func (UnimplementedRepository) GetQueuesWithExpiredMessages(ctx context.Context, now time.Time, limit int) ([]DomainEntities.QueueNameEntity, error) {
+	return nil, errUnimplemented("GetQueuesWithExpiredMessages")
+}
+
+func (UnimplementedRepository) RemoveExpired(ctx context.Context, queueName DomainEntities.QueueNameEntity, now time.Time, limit int) ([]string, error) {
+	return nil, errUnimplemented("RemoveExpired")
+}
+
+func (UnimplementedRepository) MoveExpiredMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	deadLetterQueue DomainEntities.QueueNameEntity,
+	now time.Time,
+	limit int,
+) ([]string, error) {
+	return nil, errUnimplemented("MoveExpiredMessages")
+}
+
+func (UnimplementedRepository) GetConfig(ctx context.Context, queueName DomainEntities.QueueNameEntity) (*DomainEntities.QueueConfigEntity, error) {
+	return nil, errUnimplemented("GetConfig")
+}
+
+func (UnimplementedRepository) SaveConfig(ctx context.Context, config DomainEntities.QueueConfigEntity) error {
+	return errUnimplemented("SaveConfig")
+}
+
+func (UnimplementedRepository) SetPaused(ctx context.Context, queueName DomainEntities.QueueNameEntity, paused bool) error {
+	return errUnimplemented("SetPaused")
+}
+
+func (UnimplementedRepository) ListConfigs(ctx context.Context) ([]DomainEntities.QueueConfigEntity, error) {
+	return nil, errUnimplemented("ListConfigs")
+}
+
+func (UnimplementedRepository) SearchArchive(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	filter DomainEntities.ArchiveFilterEntity,
+	cursor *DomainEntities.MessageCursorEntity,
+	limit int,
+) ([]DomainEntities.ArchivedMessageEntity, error) {
+	return nil, errUnimplemented("SearchArchive")
+}
+
+func (UnimplementedRepository) RemoveArchivedBefore(ctx context.Context, queueName DomainEntities.QueueNameEntity, completedBefore time.Time, limit int) (int64, error) {
+	return 0, errUnimplemented("RemoveArchivedBefore")
+}
+
+func (UnimplementedRepository) GetTopic(ctx context.Context, tenant string, topic string) (*DomainEntities.TopicEntity, error) {
+	return nil, errUnimplemented("GetTopic")
+}
+
+func (UnimplementedRepository) ListTopics(ctx context.Context, tenant string) ([]DomainEntities.TopicEntity, error) {
+	return nil, errUnimplemented("ListTopics")
+}
+
+func (UnimplementedRepository) SaveSubscription(ctx context.Context, tenant string, subscription DomainEntities.TopicSubscriptionEntity) error {
+	return errUnimplemented("SaveSubscription")
+}
+
+func (UnimplementedRepository) RemoveSubscription(ctx context.Context, tenant string, topic string, id string) (bool, error) {
+	return false, errUnimplemented("RemoveSubscription")
+}
+
+func (UnimplementedRepository) ListPushSubscriptions(ctx context.Context) ([]DomainEntities.PushSubscriptionEntity, error) {
+	return nil, errUnimplemented("ListPushSubscriptions")
+}
+
+func (UnimplementedRepository) GetPushSubscriptions(ctx context.Context, queueName DomainEntities.QueueNameEntity) ([]DomainEntities.PushSubscriptionEntity, error) {
+	return nil, errUnimplemented("GetPushSubscriptions")
+}
+
+func (UnimplementedRepository) SavePushSubscription(ctx context.Context, subscription DomainEntities.PushSubscriptionEntity) error {
+	return errUnimplemented("SavePushSubscription")
+}
+
+func (UnimplementedRepository) RemovePushSubscription(ctx context.Context, queueName DomainEntities.QueueNameEntity, id string) (bool, error) {
+	return false, errUnimplemented("RemovePushSubscription")
+}
+
+func (UnimplementedRepository) ListSchedules(ctx context.Context, tenant string) ([]DomainEntities.ScheduleEntity, error) {
+	return nil, errUnimplemented("ListSchedules")
+}
+
+func (UnimplementedRepository) GetSchedule(ctx context.Context, tenant string, name string) (*DomainEntities.ScheduleEntity, error) {
+	return nil, errUnimplemented("GetSchedule")
+}
+
+func (UnimplementedRepository) SaveSchedule(ctx context.Context, schedule DomainEntities.ScheduleEntity) error {
+	return errUnimplemented("SaveSchedule")
+}
+
+func (UnimplementedRepository) RemoveSchedule(ctx context.Context, tenant string, name string) (bool, error) {
+	return false, errUnimplemented("RemoveSchedule")
+}
+
+func (UnimplementedRepository) GetDueSchedules(ctx context.Context, now time.Time, limit int) ([]DomainEntities.ScheduleEntity, error) {
+	return nil, errUnimplemented("GetDueSchedules")
+}
+
+func (UnimplementedRepository) SetScheduleRun(ctx context.Context, id string, lastRunAt *time.Time, nextRunAt time.Time) error {
+	return errUnimplemented("SetScheduleRun")
+}
+
+func (UnimplementedRepository) ListApiKeys(ctx context.Context) ([]DomainEntities.ApiKeyEntity, error) {
+	return nil, errUnimplemented("ListApiKeys")
+}
+
+func (UnimplementedRepository) GetApiKeyByHash(ctx context.Context, keyHash string) (*DomainEntities.ApiKeyEntity, error) {
+	return nil, errUnimplemented("GetApiKeyByHash")
+}
+
+func (UnimplementedRepository) SaveApiKey(ctx context.Context, apiKey DomainEntities.ApiKeyEntity) error {
+	return errUnimplemented("SaveApiKey")
+}
+
+func (UnimplementedRepository) RemoveApiKey(ctx context.Context, name string) (bool, error) {
+	return false, errUnimplemented("RemoveApiKey")
+}
+
+func (UnimplementedRepository) TryLock(ctx context.Context, name string) (*InfrastructureRepositories.DatabaseLock, error) {
+	return nil, errUnimplemented("TryLock")
+}
@@ -0,0 +1,90 @@
+package InfrastructureResponses
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	DomainEntities "lean-queue/src/domain/entities"
+	InfrastructureLogger "lean-queue/src/infrastructure/logger"
+	"net"
+	"net/http"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+var statusByKind = map[DomainEntities.ErrorKind]int{
+	DomainEntities.ErrorKindValidation:      http.StatusBadRequest,
+	DomainEntities.ErrorKindUnauthorized:    http.StatusUnauthorized,
+	DomainEntities.ErrorKindForbidden:       http.StatusForbidden,
+	DomainEntities.ErrorKindNotFound:        http.StatusNotFound,
+	DomainEntities.ErrorKindConflict:        http.StatusConflict,
+	DomainEntities.ErrorKindTooManyRequests: http.StatusTooManyRequests,
+	DomainEntities.ErrorKindUnavailable:     http.StatusServiceUnavailable,
+}
+
+var errAlreadyExists = DomainEntities.NewDomainError(DomainEntities.ErrorKindConflict, "already_exists", "already exists")
+var errDatabaseUnavailable = DomainEntities.NewDomainError(DomainEntities.ErrorKindUnavailable, "database_unavailable", "database unavailable")
+
+var errInternal = DomainEntities.NewDomainError("", "internal_error", "internal error")
+
+// NewInvalidBodyError reports a request body that could not be decoded.
+func NewInvalidBodyError(err error) error {
+	return DomainEntities.NewDomainError(DomainEntities.ErrorKindValidation, "invalid_body", "invalid request body").
+		WithDetails(map[string]interface{}{"reason": err.Error()})
+}
+
+// NewInvalidParameterError reports an invalid path or query parameter.
+func NewInvalidParameterError(parameter string, reason string) error {
+	return DomainEntities.NewDomainError(DomainEntities.ErrorKindValidation, "invalid_parameter", "invalid parameter "+parameter).
+		WithDetails(map[string]interface{}{"parameter": parameter, "reason": reason})
+}
+
+// WriteError answers the request with the {code, message, details} envelope
+// of err, with the status of its kind and the message in the language of the
+// Accept-Language header. Errors that are not DomainErrors are logged and
+// answered as internal errors, without leaking their message.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainError *DomainEntities.DomainError
+	switch {
+	case errors.As(err, &domainError):
+	case isDuplicate(err):
+		domainError = errAlreadyExists
+	case isUnavailable(err):
+		InfrastructureLogger.FromContext(r.Context()).Error("database unavailable", "error", err)
+		domainError = errDatabaseUnavailable
+	default:
+		InfrastructureLogger.FromContext(r.Context()).Error("request failed", "error", err)
+		domainError = errInternal
+	}
+
+	status, ok := statusByKind[domainError.GetKind()]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	message, details := localize(r.Header.Get("Accept-Language"), domainError)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    domainError.GetCode(),
+		"message": message,
+		"details": details,
+	})
+}
+
+// isDuplicate reports a write refused by a unique key, such as a message
+// saved concurrently with the same id.
+func isDuplicate(err error) bool {
+	var mysqlError *mysql.MySQLError
+	return errors.As(err, &mysqlError) && (mysqlError.Number == 1062 || mysqlError.Number == 1022)
+}
+
+func isUnavailable(err error) bool {
+	var netError net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netError)
+}
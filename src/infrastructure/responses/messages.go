@@ -0,0 +1,109 @@
+package InfrastructureResponses
+
+import (
+	DomainEntities "lean-queue/src/domain/entities"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// translations hold the messages of every error code per language, English
+// messages being the ones of the errors themselves.
+var translations = map[string]map[string]string{
+	"pt": {
+		"validation_failed":           "Requisição inválida",
+		"invalid_body":                "Erro ao ler o corpo da requisição",
+		"invalid_parameter":           "Parâmetro inválido",
+		"invalid_api_key":             "Token inválido!",
+		"permission_denied":           "Permissão negada!",
+		"rate_limit_exceeded":         "Limite de requisições excedido",
+		"tenant_quota_exceeded":       "Cota do tenant excedida",
+		"invalid_confirmation_token":  "Token de confirmação inválido ou expirado",
+		"message_not_found":           "Mensagem não encontrada",
+		"reservation_not_found":       "Mensagem não reservada por este consumidor",
+		"topic_not_found":             "Tópico não encontrado",
+		"subscription_not_found":      "Assinatura não encontrada",
+		"push_subscription_not_found": "Assinatura push não encontrada",
+		"push_host_not_allowed":       "Host da URL push não permitido",
+		"schedule_not_found":          "Agendamento não encontrado",
+		"api_key_not_found":           "Chave de API não encontrada",
+		"route_not_found":             "Rota não encontrada",
+		"already_exists":              "Já existe",
+		"message_id_taken":            "Id de mensagem usado por outro tenant",
+		"database_unavailable":        "Banco de dados indisponível",
+		"internal_error":              "Erro interno",
+	},
+}
+
+// localize returns the message and details of err in the preferred language
+// of acceptLanguage. A translated message replaces the specific English one,
+// which then goes to the details as the reason.
+func localize(acceptLanguage string, err *DomainEntities.DomainError) (string, map[string]interface{}) {
+	details := err.GetDetails()
+
+	for _, language := range preferredLanguages(acceptLanguage) {
+		if language == "en" {
+			break
+		}
+
+		message, ok := translations[language][err.GetCode()]
+		if !ok {
+			continue
+		}
+
+		if message != err.GetMessage() {
+			localizedDetails := map[string]interface{}{}
+			for key, value := range details {
+				localizedDetails[key] = value
+			}
+			if _, ok := localizedDetails["reason"]; !ok {
+				localizedDetails["reason"] = err.GetMessage()
+			}
+			details = localizedDetails
+		}
+
+		return message, details
+	}
+
+	return err.GetMessage(), details
+}
+
+// preferredLanguages returns the primary language subtags of an
+// Accept-Language header, most preferred first.
+func preferredLanguages(acceptLanguage string) []string {
+	type weightedLanguage struct {
+		language string
+		quality  float64
+	}
+
+	var languages []weightedLanguage
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed <= 0 {
+				continue
+			}
+			quality = parsed
+		}
+
+		language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		languages = append(languages, weightedLanguage{language: language, quality: quality})
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	result := make([]string, len(languages))
+	for i, language := range languages {
+		result[i] = language.language
+	}
+
+	return result
+}
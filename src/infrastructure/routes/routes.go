@@ -0,0 +1,179 @@
+package InfrastructureRoutes
+
+import (
+	"fmt"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureControllers "lean-queue/src/infrastructure/controllers"
+	InfrastructureMiddlewares "lean-queue/src/infrastructure/middlewares"
+	InfrastructureResponses "lean-queue/src/infrastructure/responses"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Repository is every repository the routes use. The MySQL QueueRepository
+// implements all of them.
+type Repository interface {
+	DomainRepositories.QueueRepositoryInterface
+	DomainRepositories.QueueConfigRepositoryInterface
+	DomainRepositories.QueueArchiveRepositoryInterface
+	DomainRepositories.TopicRepositoryInterface
+	DomainRepositories.PushSubscriptionRepositoryInterface
+	DomainRepositories.ScheduleRepositoryInterface
+	DomainRepositories.ApiKeyRepositoryInterface
+}
+
+type Config struct {
+	// TenantsByApiKey authenticates the API keys, keys of the default
+	// tenant mapping to the zero TenantEntity
+	TenantsByApiKey map[string]DomainEntities.TenantEntity
+	// TenantsById gives the quotas of the tenants of stored API keys
+	TenantsById map[string]DomainEntities.TenantEntity
+	// AdminApiKeys are the API keys allowed to run admin operations
+	AdminApiKeys       map[string]bool
+	PublishRateLimit   InfrastructureMiddlewares.RateLimitConfig
+	ReserveRateLimit   InfrastructureMiddlewares.RateLimitConfig
+	ConfirmationSecret []byte
+	// PushAllowedHosts are the hosts push subscriptions may deliver to, any
+	// host when empty
+	PushAllowedHosts []string
+	// BatchSize is how many messages purges, redrives, exports and imports
+	// handle at a time
+	BatchSize int
+}
+
+// NewRouter registers every route of the server on a new router.
+func NewRouter(repository Repository, config Config) *mux.Router {
+	router := mux.NewRouter().StrictSlash(true)
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		InfrastructureResponses.WriteError(w, r, DomainEntities.NewNotFoundError("route_not_found", "route not found"))
+	})
+	router.Use(InfrastructureMiddlewares.NewRequestIdMiddleware().Handle)
+	router.Use(otelhttp.NewMiddleware("lean-queue",
+		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+			if route := mux.CurrentRoute(r); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil {
+					return r.Method + " " + template
+				}
+			}
+			return r.Method + " " + r.URL.Path
+		}),
+	))
+
+	apiV1Router := router.PathPrefix("/v1").Subrouter()
+	apiV1Router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			if r.Method == "OPTIONS" {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	})
+	apiV1Router.Use(InfrastructureMiddlewares.NewApiKeyAuthMiddleware(config.TenantsByApiKey, config.TenantsById, repository).Handle)
+	apiV1Router.Use(InfrastructureMiddlewares.NewRequestBodyLimitMiddleware(InfrastructureMiddlewares.MaxRequestBodyBytes).Handle)
+	apiV1Router.StrictSlash(true)
+
+	publishRateLimit := InfrastructureMiddlewares.NewRateLimitMiddleware(config.PublishRateLimit, InfrastructureMiddlewares.QueueNameFromBody)
+	reserveRateLimit := InfrastructureMiddlewares.NewRateLimitMiddleware(config.ReserveRateLimit, InfrastructureMiddlewares.QueueNameFromQuery)
+	adminOnly := InfrastructureMiddlewares.NewAdminMiddleware(config.AdminApiKeys)
+
+	controllerPublishMessage := InfrastructureControllers.NewPublishMessageController(repository, repository)
+	controllerPublishMessages := InfrastructureControllers.NewPublishMessagesController(repository, repository)
+	controllerRemoveMessage := InfrastructureControllers.NewRemoveMessageController(repository)
+	controllerGetAndReserveNextMessages := InfrastructureControllers.NewGetAndReserveNextMessagesController(repository)
+	controllerGetMessagesOnQueue := InfrastructureControllers.NewGetMessagesOnQueueController(repository)
+	controllerGetMessage := InfrastructureControllers.NewGetMessageController(repository)
+	controllerExtendMessageReservation := InfrastructureControllers.NewExtendMessageReservationController(repository)
+	controllerGetQueueConfig := InfrastructureControllers.NewGetQueueConfigController(repository)
+	controllerSaveQueueConfig := InfrastructureControllers.NewSaveQueueConfigController(repository)
+	controllerGetQueueStats := InfrastructureControllers.NewGetQueueStatsController(repository)
+	controllerSearchArchive := InfrastructureControllers.NewSearchArchiveController(repository)
+	controllerGetQueues := InfrastructureControllers.NewGetQueuesController(repository)
+	controllerRedriveQueue := InfrastructureControllers.NewRedriveQueueController(repository, repository, config.BatchSize)
+	controllerExportQueues := InfrastructureControllers.NewExportQueuesController(repository, config.BatchSize)
+	controllerImportMessages := InfrastructureControllers.NewImportMessagesController(repository, repository, config.BatchSize)
+	controllerPauseQueue := InfrastructureControllers.NewSetQueuePausedController(repository, true)
+	controllerResumeQueue := InfrastructureControllers.NewSetQueuePausedController(repository, false)
+	controllerPurgeQueue := InfrastructureControllers.NewPurgeQueueController(repository, config.ConfirmationSecret, config.BatchSize)
+	controllerGetTopics := InfrastructureControllers.NewGetTopicsController(repository)
+	controllerSubscribeTopic := InfrastructureControllers.NewSubscribeTopicController(repository)
+	controllerUnsubscribeTopic := InfrastructureControllers.NewUnsubscribeTopicController(repository)
+	controllerPublishTopicMessage := InfrastructureControllers.NewPublishTopicMessageController(repository, repository, repository)
+	controllerGetPushSubscriptions := InfrastructureControllers.NewGetPushSubscriptionsController(repository)
+	controllerCreatePushSubscription := InfrastructureControllers.NewCreatePushSubscriptionController(repository, config.PushAllowedHosts)
+	controllerRemovePushSubscription := InfrastructureControllers.NewRemovePushSubscriptionController(repository)
+	controllerGetSchedules := InfrastructureControllers.NewGetSchedulesController(repository)
+	controllerSaveSchedule := InfrastructureControllers.NewSaveScheduleController(repository)
+	controllerRemoveSchedule := InfrastructureControllers.NewRemoveScheduleController(repository)
+	controllerPauseSchedule := InfrastructureControllers.NewSetSchedulePausedController(repository, true)
+	controllerResumeSchedule := InfrastructureControllers.NewSetSchedulePausedController(repository, false)
+	controllerGetApiKeys := InfrastructureControllers.NewGetApiKeysController(repository)
+	controllerCreateApiKey := InfrastructureControllers.NewCreateApiKeyController(repository)
+	controllerRemoveApiKey := InfrastructureControllers.NewRemoveApiKeyController(repository)
+
+	apiV1Router.Handle("/api-keys", adminOnly.Handle(http.HandlerFunc(controllerGetApiKeys.Handle))).Methods("GET")
+	apiV1Router.Handle("/api-keys", adminOnly.Handle(http.HandlerFunc(controllerCreateApiKey.Handle))).Methods("POST")
+	apiV1Router.Handle("/api-keys/{name}", adminOnly.Handle(http.HandlerFunc(controllerRemoveApiKey.Handle))).Methods("DELETE")
+	apiV1Router.Handle("/message", publishRateLimit.Handle(http.HandlerFunc(controllerPublishMessage.Handle))).Methods("POST")
+	apiV1Router.HandleFunc("/message", controllerRemoveMessage.Handle).Methods("DELETE")
+	apiV1Router.Handle("/message/batch", publishRateLimit.Handle(http.HandlerFunc(controllerPublishMessages.Handle))).Methods("POST")
+	apiV1Router.Handle("/message/next", reserveRateLimit.Handle(http.HandlerFunc(controllerGetAndReserveNextMessages.Handle))).Methods("GET")
+	apiV1Router.HandleFunc("/message/queue/{queue_name}", controllerGetMessagesOnQueue.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/message/{id}", controllerGetMessage.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/message/{id}/extend", controllerExtendMessageReservation.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/queues", controllerGetQueues.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/queues/export", controllerExportQueues.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/queues/import", controllerImportMessages.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/queues/{queue_name}/config", controllerGetQueueConfig.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/queues/{queue_name}/config", controllerSaveQueueConfig.Handle).Methods("PUT")
+	apiV1Router.HandleFunc("/queues/{queue_name}/stats", controllerGetQueueStats.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/queues/{queue_name}/archive", controllerSearchArchive.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/queues/{queue_name}/pause", controllerPauseQueue.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/queues/{queue_name}/resume", controllerResumeQueue.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/queues/{queue_name}/redrive", controllerRedriveQueue.Handle).Methods("POST")
+	apiV1Router.Handle("/queues/{queue_name}/purge", adminOnly.Handle(http.HandlerFunc(controllerPurgeQueue.Handle))).Methods("POST")
+	apiV1Router.HandleFunc("/queues/{queue_name}/push-subscriptions", controllerGetPushSubscriptions.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/queues/{queue_name}/push-subscriptions", controllerCreatePushSubscription.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/queues/{queue_name}/push-subscriptions/{subscription_id}", controllerRemovePushSubscription.Handle).Methods("DELETE")
+	apiV1Router.HandleFunc("/schedules", controllerGetSchedules.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/schedules/{name}", controllerSaveSchedule.Handle).Methods("PUT")
+	apiV1Router.HandleFunc("/schedules/{name}", controllerRemoveSchedule.Handle).Methods("DELETE")
+	apiV1Router.HandleFunc("/schedules/{name}/pause", controllerPauseSchedule.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/schedules/{name}/resume", controllerResumeSchedule.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/topics", controllerGetTopics.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/topics/{topic}", controllerGetTopics.Handle).Methods("GET")
+	apiV1Router.HandleFunc("/topics/{topic}/subscriptions", controllerSubscribeTopic.Handle).Methods("POST")
+	apiV1Router.HandleFunc("/topics/{topic}/subscriptions/{subscription_id}", controllerUnsubscribeTopic.Handle).Methods("DELETE")
+	apiV1Router.Handle("/topics/{topic}/publish", publishRateLimit.HandleTopic(http.HandlerFunc(controllerPublishTopicMessage.Handle))).Methods("POST")
+
+	router.HandleFunc("/openapi.json", InfrastructureControllers.NewGetOpenApiController().Handle).Methods("GET")
+	router.HandleFunc("/docs", InfrastructureControllers.NewGetApiDocsController().Handle).Methods("GET")
+
+	router.HandleFunc(
+		"/",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("OK - Carregado."))
+		},
+	).Methods("GET")
+
+	router.HandleFunc("/alive", func(w http.ResponseWriter, r *http.Request) {
+		slog.Debug("Alive")
+		fmt.Fprintf(w, "OK")
+	})
+
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		slog.Debug("Health")
+		fmt.Fprintf(w, "OK")
+	})
+
+	return router
+}
@@ -0,0 +1,80 @@
+package InfrastructureWorkers
+
+import (
+	"context"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	InfrastructureRepositories "lean-queue/src/infrastructure/repositories"
+	"log/slog"
+	"time"
+)
+
+const janitorLockName = "lean-queue-janitor"
+
+type janitorWorker struct {
+	queueRepository *InfrastructureRepositories.QueueRepository
+	interval        time.Duration
+	batchSize       int
+}
+
+func NewJanitorWorker(
+	queueRepository *InfrastructureRepositories.QueueRepository,
+	interval time.Duration,
+	batchSize int,
+) *janitorWorker {
+	return &janitorWorker{
+		queueRepository: queueRepository,
+		interval:        interval,
+		batchSize:       batchSize,
+	}
+}
+
+// Run enforces queue policies every interval until ctx is done. Only the
+// instance holding the janitor database lock does the work.
+func (worker *janitorWorker) Run(ctx context.Context) {
+	usecase := ApplicationUsecases.NewEnforceQueuePoliciesUsecase(
+		worker.queueRepository,
+		worker.queueRepository,
+		worker.queueRepository,
+	)
+
+	var lock *InfrastructureRepositories.DatabaseLock
+	defer func() {
+		if lock != nil {
+			lock.Release()
+		}
+	}()
+
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if lock != nil && !lock.IsHeld(ctx) {
+			slog.Warn("janitor lost leadership")
+			lock.Release()
+			lock = nil
+		}
+
+		if lock == nil {
+			acquired, err := worker.queueRepository.TryLock(ctx, janitorLockName)
+			if err != nil {
+				slog.Error("janitor failed to acquire lock", "error", err)
+				continue
+			}
+			if acquired == nil {
+				continue
+			}
+			slog.Info("janitor acquired leadership")
+			lock = acquired
+		}
+
+		if err := usecase.Handle(ctx, time.Now(), worker.batchSize); err != nil {
+			slog.Error("janitor run failed", "error", err)
+		}
+	}
+}
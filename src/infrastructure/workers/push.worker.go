@@ -0,0 +1,264 @@
+package InfrastructureWorkers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	DomainRepositories "lean-queue/src/domain/repositories"
+	InfrastructureRepositories "lean-queue/src/infrastructure/repositories"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const pushLockName = "lean-queue-push"
+
+// PushRepository is every repository the push worker uses. The MySQL
+// QueueRepository implements all of them.
+type PushRepository interface {
+	DomainRepositories.QueueRepositoryInterface
+	DomainRepositories.QueueConfigRepositoryInterface
+	DomainRepositories.PushSubscriptionRepositoryInterface
+	TryLock(ctx context.Context, name string) (*InfrastructureRepositories.DatabaseLock, error)
+}
+
+type pushWorker struct {
+	queueRepository PushRepository
+	client          *http.Client
+	interval        time.Duration
+	retryBase       time.Duration
+	retryMax        time.Duration
+	allowedHosts    []string
+
+	// slots holds, per subscription id, one token per delivery in flight
+	slots map[string]chan struct{}
+}
+
+// NewPushWorker only delivers to subscriptions whose url host is one of
+// allowedHosts, any host when it is empty. Redirects are not followed, so
+// an allowed host cannot send deliveries elsewhere.
+func NewPushWorker(
+	queueRepository PushRepository,
+	interval time.Duration,
+	retryBase time.Duration,
+	retryMax time.Duration,
+	allowedHosts []string,
+) *pushWorker {
+	return &pushWorker{
+		queueRepository: queueRepository,
+		client: &http.Client{
+			CheckRedirect: func(request *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		interval:     interval,
+		retryBase:    retryBase,
+		retryMax:     retryMax,
+		allowedHosts: allowedHosts,
+		slots:        map[string]chan struct{}{},
+	}
+}
+
+// Run delivers the messages of every push subscription every interval until
+// ctx is done, then waits for deliveries in flight. Only the instance holding
+// the push database lock delivers, so concurrency limits hold cluster wide.
+func (worker *pushWorker) Run(ctx context.Context) {
+	var deliveries sync.WaitGroup
+	defer deliveries.Wait()
+
+	var lock *InfrastructureRepositories.DatabaseLock
+	defer func() {
+		if lock != nil {
+			lock.Release()
+		}
+	}()
+
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if lock != nil && !lock.IsHeld(ctx) {
+			slog.Warn("push worker lost leadership")
+			lock.Release()
+			lock = nil
+		}
+
+		if lock == nil {
+			acquired, err := worker.queueRepository.TryLock(ctx, pushLockName)
+			if err != nil {
+				slog.Error("push worker failed to acquire lock", "error", err)
+				continue
+			}
+			if acquired == nil {
+				continue
+			}
+			slog.Info("push worker acquired leadership")
+			lock = acquired
+		}
+
+		if err := worker.dispatch(ctx, &deliveries); err != nil {
+			slog.Error("push dispatch failed", "error", err)
+		}
+	}
+}
+
+// dispatch reserves, for every subscription, as many messages as it has free
+// slots and delivers each of them in its own goroutine.
+func (worker *pushWorker) dispatch(ctx context.Context, deliveries *sync.WaitGroup) error {
+	subscriptions, err := worker.queueRepository.ListPushSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	reserveUsecase := ApplicationUsecases.NewReservePushMessagesUsecase(worker.queueRepository)
+
+	subscribed := map[string]bool{}
+	for _, subscription := range subscriptions {
+		subscribed[subscription.GetId()] = true
+	}
+	for id := range worker.slots {
+		if !subscribed[id] {
+			delete(worker.slots, id)
+		}
+	}
+
+	for _, subscription := range subscriptions {
+		// Subscriptions created before the host was disallowed keep their messages
+		if !subscription.IsHostAllowed(worker.allowedHosts) {
+			slog.Warn("push subscription host is not allowed", "subscription_id", subscription.GetId(), "queue_name", subscription.GetQueueName().GetValue())
+			continue
+		}
+
+		slots, ok := worker.slots[subscription.GetId()]
+		if !ok || cap(slots) != subscription.GetMaxConcurrency() {
+			// Deliveries in flight keep releasing into the previous channel
+			slots = make(chan struct{}, subscription.GetMaxConcurrency())
+			worker.slots[subscription.GetId()] = slots
+		}
+
+		free := cap(slots) - len(slots)
+		if free == 0 {
+			continue
+		}
+
+		messages, err := reserveUsecase.Handle(ctx, subscription, free, time.Now())
+		if err != nil {
+			slog.Error("failed to reserve push messages", "subscription_id", subscription.GetId(), "queue_name", subscription.GetQueueName().GetValue(), "error", err)
+			continue
+		}
+
+		for _, message := range messages {
+			slots <- struct{}{}
+			deliveries.Add(1)
+			go func(subscription DomainEntities.PushSubscriptionEntity, message DomainEntities.QueueEntity) {
+				defer deliveries.Done()
+				defer func() { <-slots }()
+				worker.deliver(ctx, subscription, message)
+			}(subscription, message)
+		}
+	}
+
+	return nil
+}
+
+func (worker *pushWorker) deliver(ctx context.Context, subscription DomainEntities.PushSubscriptionEntity, message DomainEntities.QueueEntity) {
+	// Deliveries in flight finish even when the worker is stopping
+	ctx = context.WithoutCancel(ctx)
+
+	logger := slog.With(
+		"subscription_id", subscription.GetId(),
+		"queue_name", subscription.GetQueueName().GetValue(),
+		"message_id", message.GetId(),
+	)
+
+	err := worker.post(ctx, subscription, message)
+	if err != nil {
+		logger.Warn("push delivery failed", "reserved_count", message.GetReservedCount(), "error", err)
+	}
+
+	settleUsecase := ApplicationUsecases.NewSettlePushDeliveryUsecase(
+		worker.queueRepository,
+		worker.queueRepository,
+		worker.retryBase,
+		worker.retryMax,
+	)
+
+	if err := settleUsecase.Handle(ctx, subscription, message, err == nil, time.Now()); err != nil {
+		logger.Error("failed to settle push delivery", "error", err)
+	}
+}
+
+// post sends message to the subscription url. The X-LeanQueue-Signature
+// header is the hex HMAC-SHA256, keyed by the subscription secret, of the
+// X-LeanQueue-Timestamp header, a dot and the request body.
+func (worker *pushWorker) post(ctx context.Context, subscription DomainEntities.PushSubscriptionEntity, message DomainEntities.QueueEntity) error {
+	ctx, cancel := context.WithTimeout(ctx, subscription.GetTimeout())
+	defer cancel()
+
+	var expiresAtStr *string
+	if message.GetExpiresAt() != nil {
+		expiresAtStrC := message.GetExpiresAt().UTC().Format("2006-01-02 15:04:05.999999")
+		expiresAtStr = &expiresAtStrC
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"id":             message.GetId(),
+		"queue_name":     message.GetName().GetValue(),
+		"message":        message.GetMessage().GetValue(),
+		"attributes":     message.GetAttributes().GetValues(),
+		"group_id":       message.GetGroupId(),
+		"expires_at":     expiresAtStr,
+		"published_at":   message.GetPublishedAt().UTC().Format("2006-01-02 15:04:05.999999"),
+		"reserved_count": message.GetReservedCount(),
+	})
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(subscription.GetSecret()))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.GetUrl(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "lean-queue")
+	request.Header.Set("X-LeanQueue-Subscription-Id", subscription.GetId())
+	request.Header.Set("X-LeanQueue-Message-Id", message.GetId())
+	request.Header.Set("X-LeanQueue-Timestamp", timestamp)
+	request.Header.Set("X-LeanQueue-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	if message.GetTraceparent() != nil {
+		request.Header.Set("traceparent", *message.GetTraceparent())
+	}
+
+	response, err := worker.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,342 @@
+package InfrastructureWorkers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	DomainEntities "lean-queue/src/domain/entities"
+	InfrastructureRepositoryTest "lean-queue/src/infrastructure/repositorytest"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testRetryBase = time.Second
+	testRetryMax  = 30 * time.Second
+)
+
+// memoryPushRepository holds a single queue. Only the methods the push
+// worker reaches are implemented; the others fail.
+type memoryPushRepository struct {
+	InfrastructureRepositoryTest.UnimplementedRepository
+
+	mutex         sync.Mutex
+	subscriptions []DomainEntities.PushSubscriptionEntity
+	config        *DomainEntities.QueueConfigEntity
+	visible       []DomainEntities.QueueEntity
+	reservations  int
+	completed     []string
+	released      map[string]time.Time
+	deadLettered  map[string]string
+}
+
+func newMemoryPushRepository(t *testing.T, subscription *DomainEntities.PushSubscriptionEntity, config *DomainEntities.QueueConfigEntity, messages ...DomainEntities.QueueEntity) *memoryPushRepository {
+	t.Helper()
+
+	if config == nil {
+		var err error
+		config, err = DomainEntities.NewQueueConfig(subscription.GetQueueName(), 0, 0, 0, nil, nil, 0, 0, "", 0, false, 0, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &memoryPushRepository{
+		subscriptions: []DomainEntities.PushSubscriptionEntity{*subscription},
+		config:        config,
+		visible:       messages,
+		released:      map[string]time.Time{},
+		deadLettered:  map[string]string{},
+	}
+}
+
+func (repository *memoryPushRepository) ListPushSubscriptions(ctx context.Context) ([]DomainEntities.PushSubscriptionEntity, error) {
+	return repository.subscriptions, nil
+}
+
+func (repository *memoryPushRepository) GetConfig(ctx context.Context, queueName DomainEntities.QueueNameEntity) (*DomainEntities.QueueConfigEntity, error) {
+	return repository.config, nil
+}
+
+func (repository *memoryPushRepository) GetAndReserveMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	limit int,
+	filters []DomainEntities.AttributeFilterEntity,
+	messagesBefore time.Time,
+	updateReservedAt time.Time,
+	updateReservedBy string,
+	updateReservedInfo *string,
+	updateReservedExpires *time.Time,
+) ([]DomainEntities.QueueEntity, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.reservations++
+
+	if limit > len(repository.visible) {
+		limit = len(repository.visible)
+	}
+	reserved := repository.visible[:limit]
+	repository.visible = repository.visible[limit:]
+
+	return reserved, nil
+}
+
+func (repository *memoryPushRepository) CompleteById(ctx context.Context, tenant string, id string, completedBy *string, completedAt time.Time) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.completed = append(repository.completed, id)
+	return nil
+}
+
+func (repository *memoryPushRepository) ReleaseMessage(ctx context.Context, tenant string, id string, reservedBy string, visibleAt time.Time) (bool, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.released[id] = visibleAt
+	return true, nil
+}
+
+func (repository *memoryPushRepository) MoveExhaustedMessages(
+	ctx context.Context,
+	queueName DomainEntities.QueueNameEntity,
+	maxReceives int,
+	deadLetterQueue DomainEntities.QueueNameEntity,
+	visibleBefore time.Time,
+	limit int,
+) ([]string, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	moved := []string{}
+	for id, visibleAt := range repository.released {
+		if visibleAt.Before(visibleBefore) {
+			repository.deadLettered[id] = deadLetterQueue.GetValue()
+			moved = append(moved, id)
+		}
+	}
+
+	return moved, nil
+}
+
+func newTestSubscription(t *testing.T, url string) *DomainEntities.PushSubscriptionEntity {
+	t.Helper()
+
+	queueName, err := DomainEntities.NewTenantQueueName("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription, err := DomainEntities.NewPushSubscription(nil, *queueName, url, "test-secret", 1, 5, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return subscription
+}
+
+func newTestMessage(t *testing.T, id string, reservedCount int) DomainEntities.QueueEntity {
+	t.Helper()
+
+	queueName, err := DomainEntities.NewTenantQueueName("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return *DomainEntities.RestoreQueue(
+		id,
+		*queueName,
+		DomainEntities.RestoreQueueMessage("hello"),
+		DomainEntities.RestoreMessageAttributes(map[string]string{"type": "order"}),
+		nil,
+		nil,
+		time.Now(),
+		nil,
+		nil,
+		&reservedCount,
+		nil,
+		time.Time{},
+		nil,
+	)
+}
+
+// dispatchOnce runs a single dispatch of worker and waits for its deliveries.
+func dispatchOnce(t *testing.T, worker *pushWorker) {
+	t.Helper()
+
+	var deliveries sync.WaitGroup
+	if err := worker.dispatch(context.Background(), &deliveries); err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	deliveries.Wait()
+}
+
+func TestPushDeliversSignedMessages(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read the delivery: %v", err)
+		}
+
+		mac := hmac.New(sha256.New, []byte("test-secret"))
+		mac.Write([]byte(r.Header.Get("X-LeanQueue-Timestamp") + "."))
+		mac.Write(body)
+		if signature := r.Header.Get("X-LeanQueue-Signature"); signature != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("X-LeanQueue-Signature = %q, does not sign the timestamp and body", signature)
+		}
+		if messageId := r.Header.Get("X-LeanQueue-Message-Id"); messageId != "message-1" {
+			t.Errorf("X-LeanQueue-Message-Id = %q, want message-1", messageId)
+		}
+
+		var delivery struct {
+			Id         string            `json:"id"`
+			QueueName  string            `json:"queue_name"`
+			Message    string            `json:"message"`
+			Attributes map[string]string `json:"attributes"`
+		}
+		if err := json.Unmarshal(body, &delivery); err != nil {
+			t.Errorf("delivery is not JSON: %v", err)
+		}
+		if delivery.Id != "message-1" || delivery.QueueName != "orders" || delivery.Message != "hello" || delivery.Attributes["type"] != "order" {
+			t.Errorf("delivery = %+v, want message-1 of orders", delivery)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repository := newMemoryPushRepository(t, newTestSubscription(t, server.URL), nil, newTestMessage(t, "message-1", 1))
+	worker := NewPushWorker(repository, time.Second, testRetryBase, testRetryMax, nil)
+
+	dispatchOnce(t, worker)
+
+	if requests.Load() != 1 {
+		t.Fatalf("endpoint received %d deliveries, want 1", requests.Load())
+	}
+	if len(repository.completed) != 1 || repository.completed[0] != "message-1" {
+		t.Errorf("completed = %v, want message-1", repository.completed)
+	}
+	if len(repository.released) != 0 {
+		t.Errorf("released = %v, want none", repository.released)
+	}
+}
+
+func TestPushRetriesFailedDeliveriesWithBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		reservedCount int
+		delay         time.Duration
+	}{
+		{reservedCount: 1, delay: testRetryBase},
+		{reservedCount: 3, delay: 4 * testRetryBase},
+		{reservedCount: 10, delay: testRetryMax},
+	}
+
+	for _, test := range tests {
+		repository := newMemoryPushRepository(t, newTestSubscription(t, server.URL), nil, newTestMessage(t, "message-1", test.reservedCount))
+		worker := NewPushWorker(repository, time.Second, testRetryBase, testRetryMax, nil)
+
+		before := time.Now()
+		dispatchOnce(t, worker)
+		after := time.Now()
+
+		visibleAt, ok := repository.released["message-1"]
+		if !ok {
+			t.Fatalf("attempt %d: message was not released", test.reservedCount)
+		}
+		if visibleAt.Before(before.Add(test.delay)) || visibleAt.After(after.Add(test.delay)) {
+			t.Errorf("attempt %d: visible again in %v, want %v", test.reservedCount, visibleAt.Sub(before), test.delay)
+		}
+		if len(repository.completed) != 0 || len(repository.deadLettered) != 0 {
+			t.Errorf("attempt %d: completed %v, dead lettered %v, want neither", test.reservedCount, repository.completed, repository.deadLettered)
+		}
+	}
+}
+
+func TestPushMovesExhaustedMessagesToDeadLetterQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	subscription := newTestSubscription(t, server.URL)
+	deadLetterQueue, err := DomainEntities.NewTenantQueueName("", "orders-dlq")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := DomainEntities.NewQueueConfig(subscription.GetQueueName(), 0, 0, 3, deadLetterQueue, nil, 0, 0, "", 0, false, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repository := newMemoryPushRepository(t, subscription, config, newTestMessage(t, "message-1", 2), newTestMessage(t, "message-2", 3))
+	worker := NewPushWorker(repository, time.Second, testRetryBase, testRetryMax, nil)
+
+	// One delivery at a time: message-1 fails its second attempt, then
+	// message-2 its third and last
+	dispatchOnce(t, worker)
+	dispatchOnce(t, worker)
+
+	if queue, ok := repository.deadLettered["message-2"]; !ok || queue != "orders-dlq" {
+		t.Errorf("message-2 dead lettered into %q, want orders-dlq", queue)
+	}
+	if _, ok := repository.deadLettered["message-1"]; ok {
+		t.Errorf("message-1 dead lettered before its max receives")
+	}
+}
+
+func TestPushDoesNotFollowRedirects(t *testing.T) {
+	var redirected atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected.Add(1)
+	}))
+	defer target.Close()
+
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer server.Close()
+
+	repository := newMemoryPushRepository(t, newTestSubscription(t, server.URL), nil, newTestMessage(t, "message-1", 1))
+	worker := NewPushWorker(repository, time.Second, testRetryBase, testRetryMax, nil)
+
+	dispatchOnce(t, worker)
+
+	if redirected.Load() != 0 {
+		t.Errorf("redirect target received %d deliveries, want none", redirected.Load())
+	}
+	if _, ok := repository.released["message-1"]; !ok {
+		t.Errorf("redirected delivery was not retried")
+	}
+}
+
+func TestPushSkipsSubscriptionsToHostsNotAllowed(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	repository := newMemoryPushRepository(t, newTestSubscription(t, server.URL), nil, newTestMessage(t, "message-1", 1))
+	worker := NewPushWorker(repository, time.Second, testRetryBase, testRetryMax, []string{"hooks.example.com"})
+
+	dispatchOnce(t, worker)
+
+	if requests.Load() != 0 || repository.reservations != 0 {
+		t.Errorf("%d deliveries after %d reservations, want none", requests.Load(), repository.reservations)
+	}
+}
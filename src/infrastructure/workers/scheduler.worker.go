@@ -0,0 +1,85 @@
+package InfrastructureWorkers
+
+import (
+	"context"
+	ApplicationUsecases "lean-queue/src/application/usecases"
+	DomainEntities "lean-queue/src/domain/entities"
+	InfrastructureRepositories "lean-queue/src/infrastructure/repositories"
+	"log/slog"
+	"time"
+)
+
+const schedulerLockName = "lean-queue-scheduler"
+
+type schedulerWorker struct {
+	queueRepository *InfrastructureRepositories.QueueRepository
+	tenants         map[string]DomainEntities.TenantEntity
+	interval        time.Duration
+	batchSize       int
+}
+
+func NewSchedulerWorker(
+	queueRepository *InfrastructureRepositories.QueueRepository,
+	tenants map[string]DomainEntities.TenantEntity,
+	interval time.Duration,
+	batchSize int,
+) *schedulerWorker {
+	return &schedulerWorker{
+		queueRepository: queueRepository,
+		tenants:         tenants,
+		interval:        interval,
+		batchSize:       batchSize,
+	}
+}
+
+// Run publishes the messages of due schedules every interval until ctx is
+// done. Only the instance holding the scheduler database lock does the work.
+func (worker *schedulerWorker) Run(ctx context.Context) {
+	usecase := ApplicationUsecases.NewRunDueSchedulesUsecase(
+		worker.queueRepository,
+		worker.queueRepository,
+		worker.queueRepository,
+		worker.tenants,
+	)
+
+	var lock *InfrastructureRepositories.DatabaseLock
+	defer func() {
+		if lock != nil {
+			lock.Release()
+		}
+	}()
+
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if lock != nil && !lock.IsHeld(ctx) {
+			slog.Warn("scheduler lost leadership")
+			lock.Release()
+			lock = nil
+		}
+
+		if lock == nil {
+			acquired, err := worker.queueRepository.TryLock(ctx, schedulerLockName)
+			if err != nil {
+				slog.Error("scheduler failed to acquire lock", "error", err)
+				continue
+			}
+			if acquired == nil {
+				continue
+			}
+			slog.Info("scheduler acquired leadership")
+			lock = acquired
+		}
+
+		if err := usecase.Handle(ctx, time.Now(), worker.batchSize); err != nil {
+			slog.Error("scheduler run failed", "error", err)
+		}
+	}
+}